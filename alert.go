@@ -0,0 +1,103 @@
+// 警示狀態：各收集器回報異常，LED 閃爍提示
+package main
+
+import (
+	"log"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+)
+
+var (
+	alertMutex sync.Mutex
+	// key 為警示來源，value 為訊息
+	alerts = map[string]string{}
+
+	// 定期執行的警示檢查
+	alertChecks []func()
+)
+
+// 設定或清除警示，狀態改變時才寫入日誌
+func setAlert(key string, active bool, msg string) {
+	alertMutex.Lock()
+	defer alertMutex.Unlock()
+	old, exists := alerts[key]
+	if active {
+		if !exists || old != msg {
+			log.Printf("警示 [%s]：%s\n", key, msg)
+		}
+		alerts[key] = msg
+		return
+	}
+	if exists {
+		log.Printf("警示解除 [%s]\n", key)
+		delete(alerts, key)
+	}
+}
+
+// 目前所有警示訊息，依來源排序
+func activeAlerts() []string {
+	alertMutex.Lock()
+	defer alertMutex.Unlock()
+	keys := make([]string, 0, len(alerts))
+	for k := range alerts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	msgs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		msgs = append(msgs, alerts[k])
+	}
+	return msgs
+}
+
+// 取 .env 檔案中的 ALERT_INTERVAL 設定（秒）
+func alertInterval() time.Duration {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	interval, err := strconv.Atoi(envConfig["ALERT_INTERVAL"])
+	if err != nil || interval <= 0 {
+		return 30 * time.Second // 預設值
+	}
+	return time.Duration(interval) * time.Second
+}
+
+// 定期執行警示檢查
+func monitorAlerts() {
+	for {
+		for _, check := range alertChecks {
+			check()
+		}
+		time.Sleep(alertInterval())
+	}
+}
+
+// 有警示時 LED 閃爍，警示解除後恢復循環狀態燈號
+func alertLED() {
+	blinking := false
+	level := gpio.Low
+	for {
+		time.Sleep(500 * time.Millisecond)
+		if len(activeAlerts()) == 0 {
+			if blinking {
+				blinking = false
+				ledStateMutex.Lock()
+				if err := led1Pin.Out(gpio.Level(screens[0].looping())); err != nil {
+					log.Printf("Failed to set LED pin %s as output: %v", led1Pin, err)
+				}
+				ledStateMutex.Unlock()
+			}
+			continue
+		}
+		blinking = true
+		level = !level
+		ledStateMutex.Lock()
+		if err := led1Pin.Out(level); err != nil {
+			log.Printf("Failed to set LED pin %s as output: %v", led1Pin, err)
+		}
+		ledStateMutex.Unlock()
+	}
+}
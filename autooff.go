@@ -0,0 +1,98 @@
+// 自動關閉：一段時間沒有按下按鈕後關閉顯示器，按鈕、新的警示或 HTTP /wake 喚醒
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// 是否已閒置到需要關閉顯示器
+func (s *screen) autoOffDue() bool {
+	after := autoOffAfter(s.prefix)
+	return after > 0 && s.idle() >= after
+}
+
+// 關閉顯示器，直到按下按鈕、出現新的警示、HTTP /wake 或程式結束
+func (s *screen) runAutoOff(quitChan <-chan struct{}) {
+	log.Printf("%s 閒置，關閉顯示器", s)
+	s.setAsleep(true)
+	defer s.setAsleep(false)
+
+	s.displayOff()
+	// 關閉前已經存在的警示不會喚醒
+	known := activeAlerts()
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-quitChan:
+			return
+		case <-ticker.C:
+		}
+		if !s.autoOffDue() {
+			break
+		}
+		if msg, ok := newAlert(known); ok {
+			log.Printf("%s 警示喚醒：%s", s, msg)
+			s.touch()
+			break
+		}
+	}
+	s.displayOn()
+	// 回到頁面時不播放切換效果
+	s.lastFrame = nil
+	log.Printf("%s 開啟顯示器", s)
+}
+
+// 關閉顯示器，畫面內容保留在顯示器中
+func (s *screen) displayOff() {
+	if err := s.dev.Halt(); err != nil {
+		log.Printf("關閉顯示器失敗: %v", err)
+	}
+}
+
+// 重新開啟顯示器，任何指令都會重新開啟，這裡重送目前的對比
+func (s *screen) displayOn() {
+	if err := s.dev.SetContrast(s.contrast); err != nil {
+		log.Printf("開啟顯示器失敗: %v", err)
+	}
+}
+
+// 不在 known 中的第一個警示
+func newAlert(known []string) (string, bool) {
+	for _, msg := range activeAlerts() {
+		if !slices.Contains(known, msg) {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// 喚醒所有顯示器，與按下按鈕相同，夜間模式也暫時解除
+func wakeScreens() {
+	for _, s := range screens {
+		s.press()
+	}
+}
+
+// HTTP 端點 POST /wake，外部訊息推送時喚醒顯示器
+func handleWake(w http.ResponseWriter, _ *http.Request) {
+	log.Println("HTTP 喚醒顯示器")
+	wakeScreens()
+	fmt.Fprintln(w, "ok")
+}
+
+// 取 .env 檔案中的 AUTO_OFF 設定，幾分鐘沒有按下按鈕後關閉顯示器，0 為不關閉
+func autoOffAfter(prefix string) time.Duration {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	minutes, err := strconv.Atoi(screenEnv(prefix, "AUTO_OFF"))
+	if err != nil || minutes <= 0 {
+		return 0 // 預設值
+	}
+	return time.Duration(minutes) * time.Minute
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAutoOffDue(t *testing.T) {
+	defer func(cfg map[string]string) { envConfig = cfg }(envConfig)
+	envConfig = map[string]string{"AUTO_OFF": "5"}
+
+	s := &screen{lastInput: time.Now().Add(-10 * time.Minute)}
+	if !s.autoOffDue() {
+		t.Error("idle 10 minutes: autoOffDue() = false, want true")
+	}
+	s.touch()
+	if s.autoOffDue() {
+		t.Error("after input: autoOffDue() = true, want false")
+	}
+}
+
+// 按下按鈕後自動關閉的迴圈隨即結束並清除 asleep，這次按下仍只喚醒不切換頁面
+func TestAutoOffButtonWake(t *testing.T) {
+	defer func(cfg map[string]string) { envConfig = cfg }(envConfig)
+	envConfig = map[string]string{"AUTO_OFF": "1"}
+
+	s := &screen{stepBy: 3, lastInput: time.Now().Add(-time.Hour)}
+	s.setAsleep(true)
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		// 與 runAutoOff 相同的結束條件，不等待 ticker，按下後立即清除 asleep
+		for s.autoOffDue() {
+			runtime.Gosched()
+		}
+		s.setAsleep(false)
+	}()
+
+	s.handlePress(2)
+	<-exited
+	if got := s.currentPage(); got != 3 {
+		t.Errorf("page = %d after waking press, want 3", got)
+	}
+	s.handlePress(2)
+	if got := s.currentPage(); got != 4 {
+		t.Errorf("page = %d after next press, want 4", got)
+	}
+}
+
+// HTTP /wake 與繪製迴圈同時存取，以 go test -race 檢查
+func TestHandleWake(t *testing.T) {
+	defer func(list []*screen, cfg map[string]string) { screens, envConfig = list, cfg }(screens, envConfig)
+	// 整天都是夜間模式
+	envConfig = map[string]string{"NIGHT_SCHEDULE": "00:00-12:00 off,12:00-00:00 off"}
+	s := &screen{lastInput: time.Now().Add(-time.Hour)}
+	screens = []*screen{s}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.setAsleep(true)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			s.nightMode(time.Now())
+			s.autoOffDue()
+		}
+	}()
+	for range 10 {
+		rec := httptest.NewRecorder()
+		handleWake(rec, httptest.NewRequest(http.MethodPost, "/wake", nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("status = %d, want 200", rec.Code)
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	if s.idle() > time.Minute {
+		t.Errorf("idle = %v after wake, want reset", s.idle())
+	}
+	if !s.press() {
+		t.Error("press() = false while asleep, want true")
+	}
+	if mode := s.nightMode(time.Now()); mode != "" {
+		t.Errorf("night mode = %q during wake period, want none", mode)
+	}
+	if mode := s.nightMode(time.Now().Add(time.Hour)); mode != "off" {
+		t.Errorf("night mode = %q after wake period, want off", mode)
+	}
+}
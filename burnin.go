@@ -0,0 +1,158 @@
+// 防止 OLED 烙印：整個畫面定期位移、定期反白，閒置時顯示螢幕保護程式
+package main
+
+import (
+	"image"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 螢幕保護程式的每秒幀數，時鐘每幀移動 1 像素
+const screensaverFPS = 10
+
+// 防烙印設定
+type burnInConfig struct {
+	Shift          int           // 畫面位移的最大像素，0 為不位移
+	ShiftInterval  time.Duration // 每次位移的間隔
+	InvertInterval time.Duration // 正常與反白顯示輪流的間隔，0 為不反白
+	Saver          string        // 螢幕保護程式：none、clock、blank
+	SaverAfter     time.Duration // 沒有按下按鈕多久後啟動螢幕保護程式
+}
+
+// 依時間更新畫面位移與反白，在繪製每個頁面前呼叫
+func (s *screen) applyBurnIn(cfg burnInConfig, now time.Time) {
+	offset := image.Point{}
+	if cfg.Shift > 0 {
+		offset = shiftOffset(cfg.Shift, int(now.Unix()/int64(cfg.ShiftInterval/time.Second)))
+	}
+	s.dev.offset = offset
+
+	inverted := s.invert
+	if cfg.InvertInterval > 0 && now.Unix()/int64(cfg.InvertInterval/time.Second)%2 == 1 {
+		inverted = !inverted
+	}
+	if inverted != s.isInverted() {
+		if err := s.dev.Invert(inverted); err != nil {
+			log.Printf("設定反白失敗: %v", err)
+			return
+		}
+		s.mu.Lock()
+		s.inverted = inverted
+		s.mu.Unlock()
+	}
+}
+
+// 顯示器目前是否反白，截圖時由 HTTP 的 goroutine 讀取
+func (s *screen) isInverted() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inverted
+}
+
+// 第 step 次位移的位置，在 -limit ~ limit 的方格中蛇行來回移動，每次只移動 1 像素
+// 從中心 (0, 0) 開始，走完一個來回 (2 × (格數 - 1) 步) 後回到中心
+func shiftOffset(limit, step int) image.Point {
+	if limit <= 0 {
+		return image.Point{}
+	}
+	size := limit*2 + 1
+	cells := size * size
+	period := 2 * (cells - 1)
+	// 方格中心在蛇行路線的正中間
+	n := (step%period + period + cells/2) % period
+	if n >= cells {
+		n = period - n
+	}
+	y := n / size
+	x := n % size
+	if y%2 == 1 {
+		x = size - 1 - x
+	}
+	return image.Pt(x-limit, y-limit)
+}
+
+// 是否已閒置到需要啟動螢幕保護程式
+func (s *screen) screensaverDue(cfg burnInConfig) bool {
+	return cfg.Saver != "none" && s.idle() >= cfg.SaverAfter
+}
+
+// 顯示螢幕保護程式，直到按下按鈕或程式結束
+func (s *screen) runScreensaver(cfg burnInConfig, quitChan <-chan struct{}) {
+	log.Printf("%s 閒置，啟動螢幕保護程式 %s", s, cfg.Saver)
+	s.setAsleep(true)
+	defer s.setAsleep(false)
+	dev, img := s.dev, s.img
+	bounds := img.Bounds()
+	const height = 18
+
+	pos, dir := image.Pt(0, 0), image.Pt(1, 1)
+	ticker := time.NewTicker(time.Second / screensaverFPS)
+	defer ticker.Stop()
+	for {
+		clearImage(img)
+		if cfg.Saver == "clock" {
+			text := clockNow().Format("15:04")
+			width := digitsWidth(height, text)
+			// 碰到邊緣時反彈
+			if pos.X+dir.X < 0 || pos.X+dir.X+width > bounds.Dx() {
+				dir.X = -dir.X
+			}
+			if pos.Y+dir.Y < 0 || pos.Y+dir.Y+height > bounds.Dy() {
+				dir.Y = -dir.Y
+			}
+			pos = pos.Add(dir)
+			drawDigits(img, pos.X, pos.Y, height, text)
+		}
+		if err := dev.Draw(dev.Bounds(), img, image.Point{}); err != nil {
+			log.Fatal(err)
+		}
+
+		select {
+		case <-quitChan:
+			return
+		case <-ticker.C:
+		}
+		if !s.screensaverDue(cfg) {
+			// 回到頁面時不播放切換效果
+			s.lastFrame = nil
+			return
+		}
+	}
+}
+
+// 取 .env 檔案中的 BURNIN_SHIFT、BURNIN_SHIFT_INTERVAL、BURNIN_INVERT_INTERVAL、
+// SCREENSAVER、SCREENSAVER_AFTER 設定
+func loadBurnInConfig(prefix string) burnInConfig {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	var cfg burnInConfig
+	cfg.Shift, _ = strconv.Atoi(screenEnv(prefix, "BURNIN_SHIFT"))
+	if cfg.Shift < 0 {
+		cfg.Shift = 0 // 預設值
+	}
+	interval, err := strconv.Atoi(screenEnv(prefix, "BURNIN_SHIFT_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = 60 // 預設值
+	}
+	cfg.ShiftInterval = time.Duration(interval) * time.Second
+	minutes, err := strconv.Atoi(screenEnv(prefix, "BURNIN_INVERT_INTERVAL"))
+	if err != nil || minutes < 0 {
+		minutes = 0 // 預設值
+	}
+	cfg.InvertInterval = time.Duration(minutes) * time.Minute
+
+	cfg.Saver = strings.ToLower(strings.TrimSpace(screenEnv(prefix, "SCREENSAVER")))
+	switch cfg.Saver {
+	case "clock", "blank":
+	default:
+		cfg.Saver = "none" // 預設值
+	}
+	minutes, err = strconv.Atoi(screenEnv(prefix, "SCREENSAVER_AFTER"))
+	if err != nil || minutes <= 0 {
+		minutes = 10 // 預設值
+	}
+	cfg.SaverAfter = time.Duration(minutes) * time.Minute
+	return cfg
+}
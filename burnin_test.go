@@ -0,0 +1,40 @@
+package main
+
+import (
+	"image"
+	"testing"
+)
+
+func TestShiftOffset(t *testing.T) {
+	for _, limit := range []int{1, 2} {
+		size := limit*2 + 1
+		period := 2 * (size*size - 1)
+		if p := shiftOffset(limit, 0); p != (image.Point{}) {
+			t.Errorf("limit %d: step 0 = %v, want (0,0)", limit, p)
+		}
+		if p := shiftOffset(limit, period); p != (image.Point{}) {
+			t.Errorf("limit %d: step %d = %v, want (0,0) after a full cycle", limit, period, p)
+		}
+
+		visited := map[image.Point]bool{}
+		prev := shiftOffset(limit, 0)
+		for step := 1; step <= period; step++ {
+			p := shiftOffset(limit, step)
+			if p.X < -limit || p.X > limit || p.Y < -limit || p.Y > limit {
+				t.Errorf("limit %d: step %d = %v, outside ±%d", limit, step, p, limit)
+			}
+			// 每次只往一個方向移動 1 像素，包含回到起點的那一步
+			if d := p.Sub(prev); abs(d.X)+abs(d.Y) != 1 {
+				t.Errorf("limit %d: step %d moved %v -> %v", limit, step, prev, p)
+			}
+			visited[p] = true
+			prev = p
+		}
+		if len(visited) != size*size {
+			t.Errorf("limit %d: visited %d positions, want %d", limit, len(visited), size*size)
+		}
+	}
+	if p := shiftOffset(0, 5); p != (image.Point{}) {
+		t.Errorf("limit 0 = %v, want (0,0)", p)
+	}
+}
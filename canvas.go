@@ -0,0 +1,331 @@
+// 1 位元畫布：線條、矩形、圓形、圓弧、儀表、長條、圖示、反白文字與裁切區域
+package main
+
+import (
+	"image"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+// 畫布，所有繪製都限制在裁切區域內
+type canvas struct {
+	img  *image1bit.VerticalLSB
+	clip image.Rectangle
+}
+
+// 建立畫布，裁切區域為整個圖片
+func newCanvas(img *image1bit.VerticalLSB) *canvas {
+	return &canvas{img: img, clip: img.Bounds()}
+}
+
+// 設定裁切區域，回傳原本的區域以便還原
+func (c *canvas) setClip(r image.Rectangle) image.Rectangle {
+	old := c.clip
+	c.clip = r.Intersect(c.img.Bounds())
+	return old
+}
+
+// 還原裁切區域為整個圖片
+func (c *canvas) resetClip() {
+	c.clip = c.img.Bounds()
+}
+
+// 設定單一像素，超出裁切區域的忽略
+func (c *canvas) set(x, y int, bit image1bit.Bit) {
+	if image.Pt(x, y).In(c.clip) {
+		c.img.SetBit(x, y, bit)
+	}
+}
+
+// 以 bit 填滿矩形
+func (c *canvas) fill(r image.Rectangle, bit image1bit.Bit) {
+	r = r.Intersect(c.clip)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			c.img.SetBit(x, y, bit)
+		}
+	}
+}
+
+// 清除整個裁切區域
+func (c *canvas) clear() {
+	c.fill(c.clip, image1bit.Off)
+}
+
+// 反相矩形區域
+func (c *canvas) invert(r image.Rectangle) {
+	r = r.Intersect(c.clip)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			c.img.SetBit(x, y, !c.img.BitAt(x, y))
+		}
+	}
+}
+
+// 直線 (Bresenham)，包含兩個端點
+func (c *canvas) line(x0, y0, x1, y1 int) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	e := dx + dy
+	for {
+		c.set(x0, y0, image1bit.On)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+// 水平線
+func (c *canvas) hline(x, y, w int) {
+	c.fill(image.Rect(x, y, x+w, y+1), image1bit.On)
+}
+
+// 垂直線
+func (c *canvas) vline(x, y, h int) {
+	c.fill(image.Rect(x, y, x+1, y+h), image1bit.On)
+}
+
+// 矩形外框
+func (c *canvas) rect(r image.Rectangle) {
+	if r.Empty() {
+		return
+	}
+	c.hline(r.Min.X, r.Min.Y, r.Dx())
+	c.hline(r.Min.X, r.Max.Y-1, r.Dx())
+	c.vline(r.Min.X, r.Min.Y, r.Dy())
+	c.vline(r.Max.X-1, r.Min.Y, r.Dy())
+}
+
+// 實心矩形
+func (c *canvas) fillRect(r image.Rectangle) {
+	c.fill(r, image1bit.On)
+}
+
+// 圓角矩形，filled 為 true 時填滿
+func (c *canvas) roundRect(r image.Rectangle, radius int, filled bool) {
+	if r.Empty() {
+		return
+	}
+	radius = max(min(radius, r.Dx()/2, r.Dy()/2), 0)
+	if radius == 0 {
+		if filled {
+			c.fillRect(r)
+		} else {
+			c.rect(r)
+		}
+		return
+	}
+
+	// 四個角的圓心
+	left, right := r.Min.X+radius, r.Max.X-1-radius
+	top, bottom := r.Min.Y+radius, r.Max.Y-1-radius
+	if filled {
+		c.fillRect(image.Rect(r.Min.X, top, r.Max.X, bottom+1))
+	} else {
+		c.hline(left, r.Min.Y, right-left+1)
+		c.hline(left, r.Max.Y-1, right-left+1)
+		c.vline(r.Min.X, top, bottom-top+1)
+		c.vline(r.Max.X-1, top, bottom-top+1)
+	}
+	circlePoints(radius, func(dx, dy int) {
+		if filled {
+			c.hline(left-dx, top-dy, right-left+2*dx+1)
+			c.hline(left-dx, bottom+dy, right-left+2*dx+1)
+			return
+		}
+		c.set(left-dx, top-dy, image1bit.On)
+		c.set(right+dx, top-dy, image1bit.On)
+		c.set(left-dx, bottom+dy, image1bit.On)
+		c.set(right+dx, bottom+dy, image1bit.On)
+	})
+}
+
+// 圓形，filled 為 true 時填滿
+func (c *canvas) circle(cx, cy, radius int, filled bool) {
+	if radius < 0 {
+		return
+	}
+	circlePoints(radius, func(dx, dy int) {
+		if filled {
+			c.hline(cx-dx, cy-dy, 2*dx+1)
+			c.hline(cx-dx, cy+dy, 2*dx+1)
+			return
+		}
+		c.set(cx-dx, cy-dy, image1bit.On)
+		c.set(cx+dx, cy-dy, image1bit.On)
+		c.set(cx-dx, cy+dy, image1bit.On)
+		c.set(cx+dx, cy+dy, image1bit.On)
+	})
+}
+
+// 中點圓演算法，以第一象限的點 (dx, dy) 呼叫 fn，其他象限由呼叫端鏡射
+func circlePoints(radius int, fn func(dx, dy int)) {
+	x, y := radius, 0
+	e := 1 - radius
+	for x >= y {
+		fn(x, y)
+		fn(y, x)
+		y++
+		if e < 0 {
+			e += 2*y + 1
+		} else {
+			x--
+			e += 2*(y-x) + 1
+		}
+	}
+}
+
+// 圓弧，角度以 12 點鐘方向為 0 度、順時針增加，thick 為線條粗細
+func (c *canvas) arc(cx, cy, radius, thick int, start, end float64) {
+	if radius <= 0 || end <= start {
+		return
+	}
+	inner := float64(max(radius-thick, 0))
+	outer := float64(radius) + 0.5
+	for y := cy - radius; y <= cy+radius; y++ {
+		for x := cx - radius; x <= cx+radius; x++ {
+			dx, dy := float64(x-cx), float64(y-cy)
+			d := math.Hypot(dx, dy)
+			if d > outer || d <= inner {
+				continue
+			}
+			if angleIn(math.Atan2(dx, -dy)*180/math.Pi, start, end) {
+				c.set(x, y, image1bit.On)
+			}
+		}
+	}
+}
+
+// 角度是否在 start ~ end 之間，角度可超過 360 度
+func angleIn(a, start, end float64) bool {
+	if end-start >= 360 {
+		return true
+	}
+	a = math.Mod(a-start, 360)
+	if a < 0 {
+		a += 360
+	}
+	return a <= end-start
+}
+
+// 半圓儀表，由左 (270 度) 到右 (90 度)，依百分比填滿，外框為細線
+func (c *canvas) gauge(cx, cy, radius, thick int, pct float64) {
+	c.band(cx, cy, radius, thick, 270, 180, pct)
+}
+
+// 環形進度，由 12 點鐘方向順時針依百分比填滿
+func (c *canvas) ring(cx, cy, radius, thick int, pct float64) {
+	c.band(cx, cy, radius, thick, 0, 360, pct)
+}
+
+// 帶狀圓弧：內外兩條細線，中間依百分比填滿
+func (c *canvas) band(cx, cy, radius, thick int, start, sweep, pct float64) {
+	pct = min(max(pct, 0), 100)
+	c.arc(cx, cy, radius, 1, start, start+sweep)
+	c.arc(cx, cy, radius-thick, 1, start, start+sweep)
+	if pct > 0 {
+		c.arc(cx, cy, radius, thick+1, start, start+sweep*pct/100)
+	}
+}
+
+// 垂直長條，由下往上依百分比填滿
+func (c *canvas) vbar(r image.Rectangle, pct float64) {
+	pct = min(max(pct, 0), 100)
+	c.rect(r)
+	inner := r.Inset(2)
+	h := int(float64(inner.Dy())*pct/100 + 0.5)
+	c.fillRect(image.Rect(inner.Min.X, inner.Max.Y-h, inner.Max.X, inner.Max.Y))
+}
+
+// 水平長條，由左往右依百分比填滿
+func (c *canvas) hbar(r image.Rectangle, pct float64) {
+	pct = min(max(pct, 0), 100)
+	c.rect(r)
+	inner := r.Inset(2)
+	w := int(float64(inner.Dx())*pct/100 + 0.5)
+	c.fillRect(image.Rect(inner.Min.X, inner.Min.Y, inner.Min.X+w, inner.Max.Y))
+}
+
+// 繪製圖示，圖示中亮的像素才會繪製
+func (c *canvas) icon(x, y int, icon *image1bit.VerticalLSB) {
+	if icon == nil {
+		return
+	}
+	b := icon.Bounds()
+	for iy := b.Min.Y; iy < b.Max.Y; iy++ {
+		for ix := b.Min.X; ix < b.Max.X; ix++ {
+			if icon.BitAt(ix, iy) {
+				c.set(x+ix-b.Min.X, y+iy-b.Min.Y, image1bit.On)
+			}
+		}
+	}
+}
+
+// 繪製文字，(x, y) 為文字左上角，回傳文字寬度
+func (c *canvas) text(x, y int, s string) int {
+	return c.textBit(x, y, s, image1bit.On)
+}
+
+// 反白文字：亮底暗字，四周保留 1 像素邊距
+func (c *canvas) invertedText(x, y int, s string) int {
+	width := textWidth(s)
+	descent := textDescent()
+	// 與 drawText 相同，字的頂端在 y+descent，底端在 y+baseline+descent
+	c.fillRect(image.Rect(x-1, y+descent-1, x+width+1, y+textBaseline()+descent+1))
+	return c.textBit(x, y, s, image1bit.Off)
+}
+
+// 以指定的 bit 繪製文字
+func (c *canvas) textBit(x, y int, s string, bit image1bit.Bit) int {
+	width := textWidth(s)
+	baseline := textBaseline()
+	height := baseline + textDescent()
+	if width == 0 {
+		return 0
+	}
+	mask := image.NewAlpha(image.Rect(0, 0, width, height))
+	textMutex.Lock()
+	d := font.Drawer{
+		Dst:  mask,
+		Src:  image.Opaque,
+		Face: textFace,
+		Dot:  fixed.P(0, baseline),
+	}
+	d.DrawString(s)
+	textMutex.Unlock()
+
+	for ty := range height {
+		for tx := range width {
+			// 反鋸齒字型取一半亮度
+			if mask.AlphaAt(tx, ty).A >= 0x80 {
+				c.set(x+tx, y+ty, bit)
+			}
+		}
+	}
+	return width
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,451 @@
+package main
+
+import (
+	"image"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/font/basicfont"
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+// 在 w x h 的畫布上繪製，回傳每列的像素，# 為亮、. 為暗
+func renderCanvas(w, h int, draw func(c *canvas)) []string {
+	img := image1bit.NewVerticalLSB(image.Rect(0, 0, w, h))
+	draw(newCanvas(img))
+	return pixelRows(img)
+}
+
+// 以 # 表示點亮的像素，每列一個字串
+func pixelRows(img *image1bit.VerticalLSB) []string {
+	b := img.Bounds()
+	rows := make([]string, b.Dy())
+	for y := range b.Dy() {
+		var row strings.Builder
+		for x := range b.Dx() {
+			if img.BitAt(b.Min.X+x, b.Min.Y+y) {
+				row.WriteByte('#')
+			} else {
+				row.WriteByte('.')
+			}
+		}
+		rows[y] = row.String()
+	}
+	return rows
+}
+
+// 由 # 與 . 組成的每列字串建立圖片
+func bitImage(rows ...string) *image1bit.VerticalLSB {
+	img := image1bit.NewVerticalLSB(image.Rect(0, 0, len(rows[0]), len(rows)))
+	for y, row := range rows {
+		for x, c := range row {
+			img.SetBit(x, y, c == '#')
+		}
+	}
+	return img
+}
+
+func checkPixels(t *testing.T, got, want []string) {
+	t.Helper()
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestCanvasShapes(t *testing.T) {
+	tests := []struct {
+		name string
+		w, h int
+		draw func(c *canvas)
+		want []string
+	}{
+		{"line", 8, 5, func(c *canvas) { c.line(0, 0, 7, 4) }, []string{
+			"#.......",
+			".##.....",
+			"...##...",
+			".....##.",
+			".......#",
+		}},
+		{"line reversed", 8, 5, func(c *canvas) { c.line(7, 4, 0, 0) }, []string{
+			"#.......",
+			".##.....",
+			"...##...",
+			".....##.",
+			".......#",
+		}},
+		{"line steep", 4, 7, func(c *canvas) { c.line(3, 0, 0, 6) }, []string{
+			"...#",
+			"..#.",
+			"..#.",
+			".#..",
+			".#..",
+			"#...",
+			"#...",
+		}},
+		{"rect", 6, 4, func(c *canvas) { c.rect(image.Rect(1, 0, 5, 4)) }, []string{
+			".####.",
+			".#..#.",
+			".#..#.",
+			".####.",
+		}},
+		{"invert", 5, 3, func(c *canvas) {
+			c.fillRect(image.Rect(0, 0, 3, 3))
+			c.invert(image.Rect(1, 1, 5, 3))
+		}, []string{
+			"###..",
+			"#..##",
+			"#..##",
+		}},
+		{"roundRect", 9, 7, func(c *canvas) { c.roundRect(image.Rect(0, 0, 9, 7), 2, false) }, []string{
+			".#######.",
+			"#.......#",
+			"#.......#",
+			"#.......#",
+			"#.......#",
+			"#.......#",
+			".#######.",
+		}},
+		{"roundRect filled", 9, 7, func(c *canvas) { c.roundRect(image.Rect(0, 0, 9, 7), 2, true) }, []string{
+			".#######.",
+			"#########",
+			"#########",
+			"#########",
+			"#########",
+			"#########",
+			".#######.",
+		}},
+		{"roundRect radius 0", 5, 4, func(c *canvas) { c.roundRect(image.Rect(0, 0, 5, 4), 0, false) }, []string{
+			"#####",
+			"#...#",
+			"#...#",
+			"#####",
+		}},
+		// 半徑超過高度的一半時縮小
+		{"roundRect radius clamped", 7, 5, func(c *canvas) { c.roundRect(image.Rect(0, 0, 7, 5), 9, false) }, []string{
+			".#####.",
+			"#.....#",
+			"#.....#",
+			"#.....#",
+			".#####.",
+		}},
+		{"circle", 9, 9, func(c *canvas) { c.circle(4, 4, 3, false) }, []string{
+			".........",
+			"...###...",
+			"..#...#..",
+			".#.....#.",
+			".#.....#.",
+			".#.....#.",
+			"..#...#..",
+			"...###...",
+			".........",
+		}},
+		{"circle filled", 9, 9, func(c *canvas) { c.circle(4, 4, 3, true) }, []string{
+			".........",
+			"...###...",
+			"..#####..",
+			".#######.",
+			".#######.",
+			".#######.",
+			"..#####..",
+			"...###...",
+			".........",
+		}},
+		// 12 點鐘到 3 點鐘
+		{"arc quarter", 9, 9, func(c *canvas) { c.arc(4, 4, 4, 1, 0, 90) }, []string{
+			"....###..",
+			".....###.",
+			".......##",
+			".......##",
+			"........#",
+			".........",
+			".........",
+			".........",
+			".........",
+		}},
+		// 3 點鐘經過 6 點鐘到 9 點鐘，粗細 2
+		{"arc lower half", 9, 9, func(c *canvas) { c.arc(4, 4, 4, 2, 90, 270) }, []string{
+			".........",
+			".........",
+			".........",
+			".........",
+			"##.....##",
+			"###...###",
+			"####.####",
+			".#######.",
+			"..#####..",
+		}},
+		{"gauge 0%", 13, 8, func(c *canvas) { c.gauge(6, 7, 6, 3, 0) }, []string{
+			".............",
+			"....#####....",
+			"..####.####..",
+			".##.......##.",
+			".#...###...#.",
+			"##..##.##..##",
+			"##.##...##.##",
+			"#..#.....#..#",
+		}},
+		// 左半邊填滿，右半邊只有外框
+		{"gauge 50%", 13, 8, func(c *canvas) { c.gauge(6, 7, 6, 3, 50) }, []string{
+			".............",
+			"....#####....",
+			"..#########..",
+			".######...##.",
+			".#######...#.",
+			"######.##..##",
+			"#####...##.##",
+			"####.....#..#",
+		}},
+		{"gauge over 100%", 13, 8, func(c *canvas) { c.gauge(6, 7, 6, 3, 150) }, []string{
+			".............",
+			"....#####....",
+			"..#########..",
+			".###########.",
+			".###########.",
+			"######.######",
+			"#####...#####",
+			"####.....####",
+		}},
+		{"ring 0%", 9, 9, func(c *canvas) { c.ring(4, 4, 4, 2, 0) }, []string{
+			"..#####..",
+			".###.###.",
+			"##.###.##",
+			"####.####",
+			"#.#...#.#",
+			"####.####",
+			"##.###.##",
+			".###.###.",
+			"..#####..",
+		}},
+		// 12 點鐘到 3 點鐘填滿
+		{"ring 25%", 13, 13, func(c *canvas) { c.ring(6, 6, 6, 2, 25) }, []string{
+			"....#####....",
+			"..#########..",
+			".##.########.",
+			".#.###.#####.",
+			"####.....####",
+			"####.....####",
+			"#.#.......###",
+			"####.....####",
+			"####.....####",
+			".#.###.###.#.",
+			".##.#####.##.",
+			"..####.####..",
+			"....#####....",
+		}},
+		{"ring 100%", 9, 9, func(c *canvas) { c.ring(4, 4, 4, 2, 100) }, []string{
+			"..#####..",
+			".#######.",
+			"#########",
+			"####.####",
+			"###...###",
+			"####.####",
+			"#########",
+			".#######.",
+			"..#####..",
+		}},
+		{"vbar 0%", 5, 8, func(c *canvas) { c.vbar(image.Rect(0, 0, 5, 8), 0) }, []string{
+			"#####",
+			"#...#",
+			"#...#",
+			"#...#",
+			"#...#",
+			"#...#",
+			"#...#",
+			"#####",
+		}},
+		// 由下往上填滿，外框與填滿之間留 1 像素
+		{"vbar 50%", 5, 10, func(c *canvas) { c.vbar(image.Rect(0, 0, 5, 10), 50) }, []string{
+			"#####",
+			"#...#",
+			"#...#",
+			"#...#",
+			"#...#",
+			"#.#.#",
+			"#.#.#",
+			"#.#.#",
+			"#...#",
+			"#####",
+		}},
+		{"vbar over 100%", 5, 8, func(c *canvas) { c.vbar(image.Rect(0, 0, 5, 8), 150) }, []string{
+			"#####",
+			"#...#",
+			"#.#.#",
+			"#.#.#",
+			"#.#.#",
+			"#.#.#",
+			"#...#",
+			"#####",
+		}},
+		{"hbar 50%", 10, 5, func(c *canvas) { c.hbar(image.Rect(0, 0, 10, 5), 50) }, []string{
+			"##########",
+			"#........#",
+			"#.###....#",
+			"#........#",
+			"##########",
+		}},
+		{"icon", 10, 10, func(c *canvas) { c.icon(1, 1, builtinIcon("thermometer", 8)) }, []string{
+			"..........",
+			"....##....",
+			"...#..#...",
+			"...#..#...",
+			"...#..#...",
+			"...####...",
+			"..######..",
+			"..######..",
+			"...####...",
+			"..........",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkPixels(t, renderCanvas(tt.w, tt.h, tt.draw), tt.want)
+		})
+	}
+}
+
+// 超出畫面的部分忽略，不會繞到另一邊或 panic
+func TestCanvasClipFrameEdges(t *testing.T) {
+	tests := []struct {
+		name string
+		w, h int
+		draw func(c *canvas)
+		want []string
+	}{
+		{"rect", 6, 5, func(c *canvas) { c.rect(image.Rect(-2, -2, 4, 3)) }, []string{
+			"...#..",
+			"...#..",
+			"####..",
+			"......",
+			"......",
+		}},
+		{"fillRect", 6, 5, func(c *canvas) { c.fillRect(image.Rect(4, 3, 10, 10)) }, []string{
+			"......",
+			"......",
+			"......",
+			"....##",
+			"....##",
+		}},
+		{"line", 6, 3, func(c *canvas) { c.line(-3, 1, 8, 1) }, []string{
+			"......",
+			"######",
+			"......",
+		}},
+		{"circle", 5, 5, func(c *canvas) { c.circle(0, 0, 3, false) }, []string{
+			"...#.",
+			"...#.",
+			"..#..",
+			"##...",
+			".....",
+		}},
+		{"icon", 6, 6, func(c *canvas) { c.icon(-4, -3, builtinIcon("thermometer", 8)) }, []string{
+			".#....",
+			"##....",
+			"###...",
+			"###...",
+			"##....",
+			"......",
+		}},
+		{"setClip", 6, 6, func(c *canvas) {
+			old := c.setClip(image.Rect(1, 1, 4, 4))
+			c.fillRect(image.Rect(0, 0, 6, 6))
+			c.setClip(old)
+			c.set(5, 5, image1bit.On)
+		}, []string{
+			"......",
+			".###..",
+			".###..",
+			".###..",
+			"......",
+			".....#",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkPixels(t, renderCanvas(tt.w, tt.h, tt.draw), tt.want)
+		})
+	}
+}
+
+// 使用內建的 7x13 字型
+func withBasicFont(t *testing.T) {
+	t.Helper()
+	textMutex.Lock()
+	old := textFace
+	textFace = basicfont.Face7x13
+	textMutex.Unlock()
+	t.Cleanup(func() {
+		textMutex.Lock()
+		textFace = old
+		textMutex.Unlock()
+	})
+}
+
+func TestCanvasText(t *testing.T) {
+	withBasicFont(t)
+	var width int
+	got := renderCanvas(9, 14, func(c *canvas) { width = c.text(1, 0, "T") })
+	if width != 7 {
+		t.Errorf("text width = %d, want 7", width)
+	}
+	checkPixels(t, got, []string{
+		".........",
+		".........",
+		".........",
+		".........",
+		"..#####..",
+		"....#....",
+		"....#....",
+		"....#....",
+		"....#....",
+		"....#....",
+		"....#....",
+		"....#....",
+		"....#....",
+		".........",
+	})
+}
+
+// 反白文字：亮底暗字，四周多 1 像素
+func TestCanvasInvertedText(t *testing.T) {
+	withBasicFont(t)
+	var width int
+	got := renderCanvas(10, 17, func(c *canvas) { width = c.invertedText(1, 0, "T") })
+	if width != 7 {
+		t.Errorf("invertedText width = %d, want 7", width)
+	}
+	checkPixels(t, got, []string{
+		"..........",
+		"#########.",
+		"#########.",
+		"#########.",
+		"##.....##.",
+		"####.####.",
+		"####.####.",
+		"####.####.",
+		"####.####.",
+		"####.####.",
+		"####.####.",
+		"####.####.",
+		"####.####.",
+		"#########.",
+		"#########.",
+		"#########.",
+		"..........",
+	})
+}
+
+func TestCanvasTextClipped(t *testing.T) {
+	withBasicFont(t)
+	checkPixels(t, renderCanvas(4, 10, func(c *canvas) { c.text(-3, -5, "T") }), []string{
+		"#...",
+		"#...",
+		"#...",
+		"#...",
+		"#...",
+		"#...",
+		"#...",
+		"#...",
+		"....",
+		"....",
+	})
+}
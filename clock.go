@@ -0,0 +1,153 @@
+// 時鐘頁面：大字時間、秒數長條、日期、星期與系統時間是否已與 NTP 同步
+package main
+
+import (
+	"log"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+// NTP 同步狀態的檢查間隔
+const clockSyncInterval = time.Minute
+
+// adjtimex 的 STA_UNSYNC，系統時間尚未同步
+const staUnsync = 0x0040
+
+var (
+	clockSyncMutex   sync.Mutex
+	clockSyncChecked time.Time
+	clockSyncState   bool
+)
+
+// 繪製時鐘頁面
+func drawClockPage(img *image1bit.VerticalLSB, now time.Time, h24, synced bool) {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+
+	clock := now.Format("15:04")
+	suffix := ""
+	if !h24 {
+		clock, suffix = now.Format("03:04"), now.Format("PM")
+	}
+
+	// 標題為日期與星期，右上角為 NTP 同步狀態，直向畫面星期在標題下方
+	portrait := portraitLayout(img)
+	if portrait {
+		drawHeader(img, now.Format("01-02"))
+	} else {
+		drawHeader(img, now.Format("2006-01-02 Mon"))
+	}
+	icon := "check"
+	if !synced {
+		icon = "warning"
+	}
+	drawIcon(img, w-8, 5, 8, icon)
+
+	if compactLayout(img) {
+		text := now.Format("15:04:05")
+		if !h24 {
+			text = now.Format("03:04:05")
+		}
+		drawDigits(img, (w-digitsWidth(14, text))/2, 17, 14, text)
+		drawTextAligned(img, 0, w, 16, suffix, alignRight)
+		return
+	}
+
+	if portrait {
+		drawTextAligned(img, 0, w, 16, now.Format("Monday"), alignCenter)
+		size := fitDigits(28, w, clock)
+		drawDigits(img, (w-digitsWidth(size, clock))/2, 36, size, clock)
+		drawTextAligned(img, 0, w, 40+size, suffix, alignCenter)
+	} else {
+		const size = 28
+		width := digitsWidth(size, clock)
+		if suffix != "" {
+			width += textWidth(suffix) + 2
+		}
+		x := drawDigits(img, (w-width)/2, 20, size, clock)
+		if suffix != "" {
+			drawText(img, (w-width)/2+x+2, 20+size-textBaseline()-2, suffix)
+		}
+	}
+
+	// 秒數長條，一分鐘填滿一次
+	seconds := float64(now.Second()) + float64(now.Nanosecond())/1e9
+	drawBar(img, seconds/60*100, w, 5, 0, h-5)
+}
+
+// 目前的時間，使用 CLOCK_TZ 設定的時區
+func clockNow() time.Time {
+	return time.Now().In(clockLoc)
+}
+
+// 系統時間是否已與 NTP 同步，每分鐘檢查一次
+func clockSynced() bool {
+	clockSyncMutex.Lock()
+	defer clockSyncMutex.Unlock()
+	if time.Since(clockSyncChecked) < clockSyncInterval {
+		return clockSyncState
+	}
+	clockSyncChecked = time.Now()
+	synced, err := timedatectlSynced()
+	if err != nil {
+		// 沒有 systemd 時改用 adjtimex
+		synced = adjtimexSynced()
+	}
+	clockSyncState = synced
+	return synced
+}
+
+// 讀取 timedatectl show 的 NTPSynchronized
+func timedatectlSynced() (bool, error) {
+	out, err := exec.Command("timedatectl", "show", "-p", "NTPSynchronized", "--value").Output()
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(out)) == "yes", nil
+}
+
+// 讀取核心的時間狀態，沒有 STA_UNSYNC 旗標時視為已同步
+func adjtimexSynced() bool {
+	var tx syscall.Timex
+	state, err := syscall.Adjtimex(&tx)
+	if err != nil {
+		log.Printf("adjtimex 讀取失敗: %v", err)
+		return false
+	}
+	// TIME_ERROR 表示時間未同步
+	return state != 5 && tx.Status&staUnsync == 0
+}
+
+// 取 .env 檔案中的 SHOW_CLOCK 設定
+func shouldShowClock() bool {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return strings.ToLower(envConfig["SHOW_CLOCK"]) == "true"
+}
+
+// 取 .env 檔案中的 CLOCK_24H 設定，false 時為 12 小時制
+func clock24h() bool {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return strings.ToLower(envConfig["CLOCK_24H"]) != "false" // 預設值為 24 小時制
+}
+
+// 取 .env 檔案中的 CLOCK_TZ 設定，例如 Asia/Taipei，未設定時使用系統時區
+func clockLocation() *time.Location {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	name := strings.TrimSpace(envConfig["CLOCK_TZ"])
+	if name == "" {
+		return time.Local // 預設值
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		log.Printf("CLOCK_TZ 時區無效 %q: %v", name, err)
+		return time.Local
+	}
+	return loc
+}
@@ -0,0 +1,183 @@
+// convert 子命令：將圖片轉換為 OLED 使用的 1 位元資料
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+const convertUsage = `用法: oled-status convert [選項] <輸入圖片>
+
+將 PNG / JPEG / GIF / BMP 轉換為 1 位元 VerticalLSB 格式，取代 LCDAssistant。
+
+選項:
+`
+
+// 執行 convert 子命令
+func runConvert(args []string) error {
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	output := fs.String("o", "", "輸出檔案，未指定時輸出到標準輸出")
+	format := fs.String("format", "", "輸出格式 go、bin 或 png，未指定時依副檔名判斷")
+	varName := fs.String("var", "logoImage", "輸出 Go 原始碼時的變數名稱")
+	size := fs.String("size", "128x64", "目標尺寸，寬x高")
+	threshold := fs.Int("threshold", 128, "亮度門檻 0 ~ 255")
+	dither := fs.String("dither", "threshold", "抖動演算法 threshold 或 floyd")
+	invert := fs.Bool("invert", false, "反相")
+	scale := fs.Int("scale", 4, "預覽 PNG 的放大倍數")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), convertUsage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("需要一個輸入圖片")
+	}
+
+	width, height, err := parseSize(*size)
+	if err != nil {
+		return err
+	}
+	if *threshold < 0 || *threshold > 255 {
+		return fmt.Errorf("threshold 必須介於 0 ~ 255: %d", *threshold)
+	}
+	if *dither != "threshold" && *dither != "floyd" {
+		return fmt.Errorf("不支援的抖動演算法: %s", *dither)
+	}
+	opts := convertOptions{
+		Width:     width,
+		Height:    height,
+		Threshold: uint8(*threshold),
+		Dither:    *dither,
+		Invert:    *invert,
+	}
+
+	images, _, err := decodeFrames(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	frames := make([]*image1bit.VerticalLSB, 0, len(images))
+	for _, src := range images {
+		frames = append(frames, convertTo1Bit(src, opts))
+	}
+
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*output)), ".")
+		if *format == "" {
+			*format = "go"
+		}
+	}
+
+	var buf bytes.Buffer
+	switch *format {
+	case "go":
+		writeGoFrames(&buf, *varName, filepath.Base(fs.Arg(0)), frames)
+	case "bin":
+		for _, f := range frames {
+			buf.Write(f.Pix)
+		}
+	case "png":
+		if err := png.Encode(&buf, previewFrames(frames, *scale)); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("不支援的輸出格式: %s", *format)
+	}
+
+	if *output == "" {
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	return os.WriteFile(*output, buf.Bytes(), 0644)
+}
+
+// 解析 寬x高
+func parseSize(s string) (int, int, error) {
+	ws, hs, found := strings.Cut(strings.ToLower(s), "x")
+	w, err1 := strconv.Atoi(ws)
+	h, err2 := strconv.Atoi(hs)
+	if !found || err1 != nil || err2 != nil || w <= 0 || h <= 0 || h%8 != 0 {
+		return 0, 0, fmt.Errorf("尺寸格式錯誤，高度需為 8 的倍數: %s", s)
+	}
+	return w, h, nil
+}
+
+// 輸出與 image.go 中 logoImage 相同格式的 Go 原始碼
+func writeGoFrames(buf *bytes.Buffer, name, source string, frames []*image1bit.VerticalLSB) {
+	fmt.Fprintln(buf, "package main")
+	fmt.Fprintln(buf)
+	fmt.Fprintf(buf, "// 由 oled-status convert 從 %s 產生，VerticalLSB 垂直掃描格式\n", source)
+	fmt.Fprintln(buf, "// 可以存放多個圖片形成動畫，{} 裡面代表一個圖片")
+	fmt.Fprintf(buf, "var %s = [][]byte{\n", name)
+	for _, f := range frames {
+		fmt.Fprintln(buf, "\t{ // 一張圖片")
+		for i := 0; i < len(f.Pix); i += 16 {
+			buf.WriteString("\t\t")
+			for j, b := range f.Pix[i:min(i+16, len(f.Pix))] {
+				if j > 0 {
+					buf.WriteByte(' ')
+				}
+				fmt.Fprintf(buf, "0x%02X,", b)
+			}
+			buf.WriteByte('\n')
+		}
+		fmt.Fprintln(buf, "\t},")
+	}
+	fmt.Fprintln(buf, "}")
+}
+
+// 放大並垂直排列所有幀，作為預覽圖
+func previewFrames(frames []*image1bit.VerticalLSB, scale int) *image.Gray {
+	scale = max(scale, 1)
+	if len(frames) == 0 {
+		return image.NewGray(image.Rect(0, 0, 1, 1))
+	}
+	w, h := frames[0].Bounds().Dx(), frames[0].Bounds().Dy()
+	// 幀與幀之間以 1 像素 (放大前) 的灰線分隔
+	out := image.NewGray(image.Rect(0, 0, w*scale, (h+1)*len(frames)*scale-scale))
+	for i, f := range frames {
+		top := i * (h + 1) * scale
+		drawScaled(out, f, image.Pt(0, top), scale)
+		if i > 0 {
+			for y := top - scale; y < top; y++ {
+				for x := range w * scale {
+					out.SetGray(x, y, color.Gray{Y: 0x40})
+				}
+			}
+		}
+	}
+	return out
+}
+
+// 將 1 位元圖片放大繪製到灰階圖片
+func drawScaled(dst *image.Gray, src *image1bit.VerticalLSB, at image.Point, scale int) {
+	b := src.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if !src.BitAt(x, y) {
+				continue
+			}
+			for sy := range scale {
+				for sx := range scale {
+					dst.SetGray(at.X+(x-b.Min.X)*scale+sx, at.Y+(y-b.Min.Y)*scale+sy, color.Gray{Y: 0xFF})
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		w, h int
+		ok   bool
+	}{
+		{"128x64", 128, 64, true},
+		{"128X32", 128, 32, true},
+		{"128x", 0, 0, false},
+		{"x64", 0, 0, false},
+		{"128", 0, 0, false},
+		{"0x64", 0, 0, false},
+		{"128x0", 0, 0, false},
+		{"128x30", 0, 0, false},
+		{"abcx64", 0, 0, false},
+		{"128xsixty", 0, 0, false},
+	}
+	for _, tt := range tests {
+		w, h, err := parseSize(tt.in)
+		if (err == nil) != tt.ok || w != tt.w || h != tt.h {
+			t.Errorf("parseSize(%q) = %d, %d, %v, want %d, %d, ok %v", tt.in, w, h, err, tt.w, tt.h, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// 七段顯示器風格的大數字，使用絕對像素座標與任意高度
+package main
+
+import (
+	"image"
+
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+// 七段顯示器的各段，由上方順時針 a ~ f，中間為 g
+const (
+	segA = 1 << iota
+	segB
+	segC
+	segD
+	segE
+	segF
+	segG
+)
+
+// 可顯示的字元與對應的段
+var segmentMap = map[rune]int{
+	'0': segA | segB | segC | segD | segE | segF,
+	'1': segB | segC,
+	'2': segA | segB | segD | segE | segG,
+	'3': segA | segB | segC | segD | segG,
+	'4': segB | segC | segF | segG,
+	'5': segA | segC | segD | segF | segG,
+	'6': segA | segC | segD | segE | segF | segG,
+	'7': segA | segB | segC,
+	'8': segA | segB | segC | segD | segE | segF | segG,
+	'9': segA | segB | segC | segD | segF | segG,
+	'-': segG,
+	'A': segA | segB | segC | segE | segF | segG,
+	'C': segA | segD | segE | segF,
+	'E': segA | segD | segE | segF | segG,
+	'F': segA | segE | segF | segG,
+	'H': segB | segC | segE | segF | segG,
+	'L': segD | segE | segF,
+	'P': segA | segB | segE | segF | segG,
+	'U': segB | segC | segD | segE | segF,
+	'b': segC | segD | segE | segF | segG,
+	'd': segB | segC | segD | segE | segG,
+	'n': segC | segE | segG,
+	'o': segC | segD | segE | segG,
+	'r': segE | segG,
+}
+
+// 數字的寬度與線條粗細
+func digitMetrics(height int) (width, thick int) {
+	return max(height/2, 3), max(height/8, 1)
+}
+
+// 單一字元的前進寬度
+func digitAdvance(height int, r rune) int {
+	width, thick := digitMetrics(height)
+	gap := thick + 1
+	switch r {
+	case '.', ':':
+		return thick*2 + gap
+	case '°':
+		return width/2 + gap
+	case '%':
+		return width + thick + gap
+	}
+	return width + gap
+}
+
+// 文字總寬度，用於置中或靠右對齊
+func digitsWidth(height int, text string) int {
+	total := 0
+	for _, r := range text {
+		total += digitAdvance(height, r)
+	}
+	// 最後一個字元不需要間距
+	_, thick := digitMetrics(height)
+	return max(total-thick-1, 0)
+}
+
+// 寬度不超過 width 的最大高度，最大為 height，直向畫面時縮小數字
+func fitDigits(height, width int, text string) int {
+	for height > 8 && digitsWidth(height, text) > width {
+		height--
+	}
+	return height
+}
+
+// 從 (x, y) 左上角開始繪製大數字，回傳繪製的寬度
+func drawDigits(img *image1bit.VerticalLSB, x, y, height int, text string) int {
+	c := newCanvas(img)
+	width, thick := digitMetrics(height)
+	start := x
+	for _, r := range text {
+		switch r {
+		case '.':
+			c.fillRect(image.Rect(x, y+height-thick*2, x+thick*2, y+height))
+		case ':':
+			c.fillRect(image.Rect(x, y+height/3-thick, x+thick*2, y+height/3+thick))
+			c.fillRect(image.Rect(x, y+height*2/3-thick, x+thick*2, y+height*2/3+thick))
+		case '°':
+			// 度的符號，空心方框
+			size := width / 2
+			c.fillRect(image.Rect(x, y, x+size, y+thick))
+			c.fillRect(image.Rect(x, y+size-thick, x+size, y+size))
+			c.fillRect(image.Rect(x, y, x+thick, y+size))
+			c.fillRect(image.Rect(x+size-thick, y, x+size, y+size))
+		case '%':
+			drawPercent(c, x, y, width+thick, height, thick)
+		default:
+			drawSegments(c, x, y, width, height, thick, segmentMap[r])
+		}
+		x += digitAdvance(height, r)
+	}
+	return max(x-start-thick-1, 0)
+}
+
+// 繪製七段
+func drawSegments(c *canvas, x, y, width, height, thick, segs int) {
+	mid := y + (height-thick)/2
+	half := (height + thick) / 2
+	if segs&segA != 0 {
+		c.fillRect(image.Rect(x, y, x+width, y+thick))
+	}
+	if segs&segB != 0 {
+		c.fillRect(image.Rect(x+width-thick, y, x+width, y+half))
+	}
+	if segs&segC != 0 {
+		c.fillRect(image.Rect(x+width-thick, mid, x+width, y+height))
+	}
+	if segs&segD != 0 {
+		c.fillRect(image.Rect(x, y+height-thick, x+width, y+height))
+	}
+	if segs&segE != 0 {
+		c.fillRect(image.Rect(x, mid, x+thick, y+height))
+	}
+	if segs&segF != 0 {
+		c.fillRect(image.Rect(x, y, x+thick, y+half))
+	}
+	if segs&segG != 0 {
+		c.fillRect(image.Rect(x, mid, x+width, mid+thick))
+	}
+}
+
+// 百分比符號：左上、右下兩個小方塊與斜線
+func drawPercent(c *canvas, x, y, width, height, thick int) {
+	box := max(width/3, thick*2)
+	c.fillRect(image.Rect(x, y, x+box, y+box))
+	c.fillRect(image.Rect(x+width-box, y+height-box, x+width, y+height))
+	for i := range height {
+		px := x + width - 1 - i*width/height
+		c.fillRect(image.Rect(px-thick/2, y+i, px-thick/2+thick, y+i+1))
+	}
+}
@@ -0,0 +1,215 @@
+// 顯示器型號與連線設定：SSD1306、SSD1309、SH1106，128x64 或 128x32，I2C 或 SPI
+package main
+
+import (
+	"fmt"
+	"image"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/i2c/i2creg"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/spi"
+	"periph.io/x/conn/v3/spi/spireg"
+	"periph.io/x/devices/v3/ssd1306"
+)
+
+// 顯示器驅動程式，ssd1306.Dev 與 sh1106Dev 都符合
+type oledDriver interface {
+	Bounds() image.Rectangle
+	Draw(r image.Rectangle, src image.Image, sp image.Point) error
+	Write(pixels []byte) (int, error)
+	Halt() error
+	SetContrast(level byte) error
+	Invert(blackOnWhite bool) error
+}
+
+// 顯示器設定
+type displayConfig struct {
+	Model string // ssd1306、ssd1309、sh1106
+	W     int
+	H     int
+	Iface string // 連線方式：i2c、spi
+	Addr  uint16 // I2C 位址
+	Bus   string // I2C 匯流排名稱，空白為第一個
+	Port  string // SPI 埠名稱，空白為第一個
+	DC    string // SPI 的 Data/Command 腳位
+	Reset string // SPI 的 Reset 腳位，空白為不使用
+
+	Rotate int  // 順時針旋轉角度：0、90、180、270
+	FlipH  bool // 左右鏡像
+	FlipV  bool // 上下鏡像
+	Invert bool // 反白顯示：亮底暗字
+}
+
+// SPI 時脈，與 ssd1306.NewSPI 相同
+const spiFreq = 3300 * physic.KiloHertz
+
+// 開啟顯示器，回傳的 close 用於關閉匯流排
+func openDisplay(cfg displayConfig) (oledDriver, func() error, error) {
+	if cfg.Iface == "spi" {
+		return openSPIDisplay(cfg)
+	}
+
+	bus, err := i2creg.Open(cfg.Bus)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var dev oledDriver
+	switch cfg.Model {
+	case "sh1106":
+		dev, err = newSH1106(&i2c.Dev{Bus: bus, Addr: cfg.Addr}, nil, cfg.W, cfg.H)
+	default:
+		opts := ssd1306Opts(cfg)
+		dev, err = ssd1306.NewI2C(&i2cAddrBus{Bus: bus, addr: cfg.Addr}, &opts)
+	}
+	if err != nil {
+		bus.Close()
+		return nil, nil, fmt.Errorf("%s: %w", cfg.Model, err)
+	}
+	return dev, bus.Close, nil
+}
+
+// 開啟 SPI 連線的顯示器，有設定 Reset 腳位時先重置
+func openSPIDisplay(cfg displayConfig) (oledDriver, func() error, error) {
+	dc := gpioreg.ByName(cfg.DC)
+	if dc == nil {
+		return nil, nil, fmt.Errorf("找不到 SPI_DC 腳位: %q", cfg.DC)
+	}
+	if cfg.Reset != "" {
+		rst := gpioreg.ByName(cfg.Reset)
+		if rst == nil {
+			return nil, nil, fmt.Errorf("找不到 SPI_RST 腳位: %q", cfg.Reset)
+		}
+		if err := resetDisplay(rst); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	port, err := spireg.Open(cfg.Port)
+	if err != nil {
+		return nil, nil, err
+	}
+	dev, err := newSPIDriver(cfg, port, dc)
+	if err != nil {
+		port.Close()
+		return nil, nil, fmt.Errorf("%s: %w", cfg.Model, err)
+	}
+	return dev, port.Close, nil
+}
+
+// 在已開啟的 SPI 埠上建立驅動程式
+func newSPIDriver(cfg displayConfig, port spi.Port, dc gpio.PinOut) (oledDriver, error) {
+	if cfg.Model == "sh1106" {
+		if err := dc.Out(gpio.Low); err != nil {
+			return nil, err
+		}
+		c, err := port.Connect(spiFreq, spi.Mode0, 8)
+		if err != nil {
+			return nil, err
+		}
+		return newSH1106(c, dc, cfg.W, cfg.H)
+	}
+	opts := ssd1306Opts(cfg)
+	return ssd1306.NewSPI(port, dc, &opts)
+}
+
+// 拉低 Reset 腳位重置顯示器
+func resetDisplay(rst gpio.PinOut) error {
+	if err := rst.Out(gpio.Low); err != nil {
+		return err
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err := rst.Out(gpio.High); err != nil {
+		return err
+	}
+	time.Sleep(10 * time.Millisecond)
+	return nil
+}
+
+// SSD1306 與 SSD1309 的設定，SSD1309 的指令與 SSD1306 相容
+func ssd1306Opts(cfg displayConfig) ssd1306.Opts {
+	opts := ssd1306.DefaultOpts
+	opts.W = cfg.W
+	opts.H = cfg.H
+	// 128x32 面板的 COM 腳位為循序配置
+	opts.Sequential = cfg.H <= 32
+	return opts
+}
+
+// 轉換 I2C 位址，ssd1306.NewI2C 固定使用 0x3C
+type i2cAddrBus struct {
+	i2c.Bus
+	addr uint16
+}
+
+func (b *i2cAddrBus) Tx(_ uint16, w, r []byte) error {
+	return b.Bus.Tx(b.addr, w, r)
+}
+
+// 畫面高度不足 64 時使用精簡版面：標題加一行內容，沒有分隔線
+func compactLayout(img image.Image) bool {
+	return img.Bounds().Dy() < 64
+}
+
+// DISPLAY_ROTATE 為 90 或 270 的直向畫面，寬度不足時內建頁面改為上下排列
+func portraitLayout(img image.Image) bool {
+	return img.Bounds().Dx() < img.Bounds().Dy()
+}
+
+// 取 .env 檔案中的 DISPLAY_MODEL、DISPLAY_BUS、DISPLAY_W、DISPLAY_H、I2C_ADDR、I2C_BUS、
+// SPI_PORT、SPI_DC、SPI_RST、DISPLAY_ROTATE、DISPLAY_FLIP_H、DISPLAY_FLIP_V、DISPLAY_INVERT 設定，
+// 多個顯示器時可加上前綴，例如 NET_I2C_ADDR
+func loadDisplayConfig(prefix string) displayConfig {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	cfg := displayConfig{
+		Model: strings.ToLower(strings.TrimSpace(screenEnv(prefix, "DISPLAY_MODEL"))),
+		Iface: strings.ToLower(strings.TrimSpace(screenEnv(prefix, "DISPLAY_BUS"))),
+		Bus:   strings.TrimSpace(screenEnv(prefix, "I2C_BUS")),
+		Port:  strings.TrimSpace(screenEnv(prefix, "SPI_PORT")),
+		DC:    strings.TrimSpace(screenEnv(prefix, "SPI_DC")),
+		Reset: strings.TrimSpace(screenEnv(prefix, "SPI_RST")),
+	}
+	if cfg.Iface != "spi" {
+		cfg.Iface = "i2c" // 預設值
+	}
+	if cfg.DC == "" {
+		cfg.DC = "GPIO25" // 預設值
+	}
+	switch cfg.Model {
+	case "ssd1306", "ssd1309", "sh1106":
+	default:
+		cfg.Model = "ssd1306" // 預設值
+	}
+	cfg.W, _ = strconv.Atoi(screenEnv(prefix, "DISPLAY_W"))
+	if cfg.W <= 0 {
+		cfg.W = 128 // 預設值
+	}
+	cfg.H, _ = strconv.Atoi(screenEnv(prefix, "DISPLAY_H"))
+	if cfg.H <= 0 {
+		cfg.H = 64 // 預設值
+	}
+	addr, err := strconv.ParseUint(screenEnv(prefix, "I2C_ADDR"), 0, 16)
+	if err != nil || addr == 0 {
+		addr = 0x3C // 預設值
+	}
+	cfg.Addr = uint16(addr)
+	cfg.Rotate, _ = strconv.Atoi(strings.TrimSpace(screenEnv(prefix, "DISPLAY_ROTATE")))
+	switch cfg.Rotate {
+	case 0, 90, 180, 270:
+	default:
+		log.Printf("DISPLAY_ROTATE 只支援 0、90、180、270: %d", cfg.Rotate)
+		cfg.Rotate = 0 // 預設值
+	}
+	cfg.FlipH = strings.ToLower(screenEnv(prefix, "DISPLAY_FLIP_H")) == "true"
+	cfg.FlipV = strings.ToLower(screenEnv(prefix, "DISPLAY_FLIP_V")) == "true"
+	cfg.Invert = strings.ToLower(screenEnv(prefix, "DISPLAY_INVERT")) == "true"
+	return cfg
+}
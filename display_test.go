@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"strings"
+	"testing"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+	"periph.io/x/conn/v3/spi"
+	"periph.io/x/conn/v3/spi/spireg"
+	"periph.io/x/conn/v3/spi/spitest"
+)
+
+// SSD1306 與 SSD1309 使用 ssd1306.NewSPI，指令與資料同樣以 DC 腳位區分
+func TestNewSPIDriverSSD1306(t *testing.T) {
+	for _, model := range []string{"ssd1306", "ssd1309"} {
+		t.Run(model, func(t *testing.T) {
+			rec := &spitest.Record{}
+			dc := newDCRecorder(rec)
+			dev, err := newSPIDriver(displayConfig{Model: model, W: 128, H: 64}, rec, dc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dev.Bounds() != image.Rect(0, 0, 128, 64) {
+				t.Errorf("bounds = %v, want 128x64", dev.Bounds())
+			}
+			ops := spiOps(rec, dc)
+			if len(ops) == 0 {
+				t.Fatal("no init commands sent")
+			}
+			for i, op := range ops {
+				if op.kind != 'C' {
+					t.Errorf("init op %d sent as data: % x", i, op.w)
+				}
+			}
+
+			frame := bytes.Repeat([]byte{0x55}, 128*64/8)
+			before := len(ops)
+			if _, err := dev.Write(frame); err != nil {
+				t.Fatal(err)
+			}
+			// 每個 page 先以指令設定位址，資料合起來應為整個畫面
+			var data []byte
+			for _, op := range spiOps(rec, dc)[before:] {
+				if op.kind == 'D' {
+					data = append(data, op.w...)
+				}
+			}
+			if !bytes.Equal(data, frame) {
+				t.Errorf("frame data = %d bytes, want %d bytes of the frame", len(data), len(frame))
+			}
+		})
+	}
+}
+
+// 註冊測試用的 GPIO 腳位，測試結束時移除
+func registerTestPin(t *testing.T, name string) *gpiotest.Pin {
+	t.Helper()
+	p := &gpiotest.Pin{N: name, Num: -1}
+	if err := gpioreg.Register(p); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { gpioreg.Unregister(name) })
+	return p
+}
+
+// 註冊測試用的 SPI 埠，開啟時回傳 rec
+func registerTestPort(t *testing.T, name string, rec *spitest.Record) {
+	t.Helper()
+	err := spireg.Register(name, nil, -1, func() (spi.PortCloser, error) { return rec, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { spireg.Unregister(name) })
+}
+
+func TestOpenSPIDisplay(t *testing.T) {
+	dc := registerTestPin(t, "TEST_DC")
+	rst := registerTestPin(t, "TEST_RST")
+	rec := &spitest.Record{}
+	registerTestPort(t, "TEST_SPI", rec)
+
+	cfg := displayConfig{Model: "sh1106", W: 128, H: 64, Iface: "spi", Port: "TEST_SPI", DC: "TEST_DC", Reset: "TEST_RST"}
+	dev, closeBus, err := openDisplay(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeBus()
+	if _, ok := dev.(*sh1106Dev); !ok {
+		t.Errorf("driver = %T, want *sh1106Dev", dev)
+	}
+	// 重置後 Reset 腳位保持 High，最後一個指令傳送時 DC 為 Low
+	if rst.Read() != gpio.High {
+		t.Error("reset pin left low")
+	}
+	if dc.Read() != gpio.Low {
+		t.Error("DC pin not low after init command")
+	}
+	if len(rec.Ops) != 1 || rec.Ops[0].W[0] != 0xAE {
+		t.Errorf("init ops = %v, want one command starting with 0xAE", rec.Ops)
+	}
+}
+
+func TestOpenSPIDisplayMissingPins(t *testing.T) {
+	registerTestPin(t, "TEST_DC2")
+	registerTestPort(t, "TEST_SPI2", &spitest.Record{})
+
+	tests := []struct {
+		name string
+		cfg  displayConfig
+		want string
+	}{
+		{"dc", displayConfig{Port: "TEST_SPI2", DC: "NO_SUCH_PIN"}, "SPI_DC"},
+		{"reset", displayConfig{Port: "TEST_SPI2", DC: "TEST_DC2", Reset: "NO_SUCH_PIN"}, "SPI_RST"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Model, tt.cfg.W, tt.cfg.H = "ssd1306", 128, 64
+			_, _, err := openSPIDisplay(tt.cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %s error", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadDisplayConfigSPI(t *testing.T) {
+	defer func(cfg map[string]string) { envConfig = cfg }(envConfig)
+	envConfig = map[string]string{
+		"DISPLAY_BUS":        "SPI",
+		"DISPLAY_MODEL":      "sh1106",
+		"SPI_PORT":           "/dev/spidev0.0",
+		"NET_SPI_DC":         "GPIO24",
+		"NET_SPI_RST":        "GPIO23",
+		"NET_DISPLAY_FLIP_H": "true",
+	}
+	cfg := loadDisplayConfig("NET_")
+	want := displayConfig{
+		Model: "sh1106", W: 128, H: 64, Iface: "spi", Addr: 0x3C,
+		Port: "/dev/spidev0.0", DC: "GPIO24", Reset: "GPIO23", FlipH: true,
+	}
+	if cfg != want {
+		t.Errorf("loadDisplayConfig() = %+v, want %+v", cfg, want)
+	}
+
+	// 沒有前綴的設定使用預設的 DC 腳位
+	if cfg := loadDisplayConfig(""); cfg.DC != "GPIO25" || cfg.Reset != "" {
+		t.Errorf("default DC %q reset %q, want GPIO25 and none", cfg.DC, cfg.Reset)
+	}
+}
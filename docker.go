@@ -0,0 +1,246 @@
+// Docker 容器狀態
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 容器資訊
+type containerInfo struct {
+	ID        string
+	Name      string
+	State     string // running、exited ...
+	Unhealthy bool
+	CPUPct    float64
+	MemUsed   float64 // bytes
+	MemLimit  float64 // bytes
+}
+
+// 容器統計摘要
+type dockerSummary struct {
+	Running    int
+	Stopped    int
+	Unhealthy  int
+	Containers []containerInfo
+}
+
+// Docker Engine API 客戶端，經由 Unix socket 連線
+type dockerClient struct {
+	socket string
+	http   *http.Client
+}
+
+// 同時讀取容器 stats 的數量上限
+const dockerStatsWorkers = 4
+
+var (
+	dockerClientMutex  sync.Mutex
+	sharedDockerClient *dockerClient
+)
+
+func newDockerClient(socket string) *dockerClient {
+	return &dockerClient{
+		socket: socket,
+		http: &http.Client{
+			Timeout: 5 * time.Second,
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", socket)
+				},
+				// 頁面每隔 SLEEP_TIME 才讀取一次，閒置的連線不需要保留太久
+				MaxIdleConns:    2,
+				IdleConnTimeout: 30 * time.Second,
+			},
+		},
+	}
+}
+
+// 共用的客戶端，DOCKER_SOCKET 變更時重新建立並關閉舊的連線
+func getDockerClient(socket string) *dockerClient {
+	dockerClientMutex.Lock()
+	defer dockerClientMutex.Unlock()
+	if sharedDockerClient == nil || sharedDockerClient.socket != socket {
+		if sharedDockerClient != nil {
+			sharedDockerClient.http.CloseIdleConnections()
+		}
+		sharedDockerClient = newDockerClient(socket)
+	}
+	return sharedDockerClient
+}
+
+// 呼叫 API 並解析 JSON，主機名稱不會被使用
+func (c *dockerClient) get(path string, v any) error {
+	resp, err := c.http.Get("http://docker" + path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("docker api %s: %s", path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// 獲取 容器 狀態與資源使用
+func (c *dockerClient) summary() (dockerSummary, error) {
+	var s dockerSummary
+	var list []struct {
+		ID     string   `json:"Id"`
+		Names  []string `json:"Names"`
+		State  string   `json:"State"`
+		Status string   `json:"Status"`
+	}
+	if err := c.get("/containers/json?all=1", &list); err != nil {
+		return s, err
+	}
+
+	for _, item := range list {
+		info := containerInfo{
+			ID:        item.ID,
+			State:     item.State,
+			Unhealthy: strings.Contains(item.Status, "(unhealthy)"),
+		}
+		if len(item.Names) > 0 {
+			info.Name = strings.TrimPrefix(item.Names[0], "/")
+		}
+		if info.State == "running" {
+			s.Running++
+		} else {
+			s.Stopped++
+		}
+		if info.Unhealthy {
+			s.Unhealthy++
+		}
+		s.Containers = append(s.Containers, info)
+	}
+
+	// 每個容器的 stats 約需 2 秒，同時讀取但限制並行數量
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, dockerStatsWorkers)
+	for i := range s.Containers {
+		info := &s.Containers[i]
+		if info.State != "running" {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := c.stats(info); err != nil {
+				log.Printf("讀取容器 %s 資源使用失敗: %v", info.Name, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return s, nil
+}
+
+// 讀取單一容器的 CPU / 記憶體使用量
+func (c *dockerClient) stats(info *containerInfo) error {
+	var st struct {
+		CPUStats    dockerCPUStats `json:"cpu_stats"`
+		PreCPUStats dockerCPUStats `json:"precpu_stats"`
+		MemoryStats struct {
+			Usage float64            `json:"usage"`
+			Limit float64            `json:"limit"`
+			Stats map[string]float64 `json:"stats"`
+		} `json:"memory_stats"`
+	}
+	if err := c.get("/containers/"+info.ID+"/stats?stream=false", &st); err != nil {
+		return err
+	}
+
+	cpuDelta := st.CPUStats.CPUUsage.TotalUsage - st.PreCPUStats.CPUUsage.TotalUsage
+	systemDelta := st.CPUStats.SystemUsage - st.PreCPUStats.SystemUsage
+	cpus := st.CPUStats.OnlineCPUs
+	if cpus == 0 {
+		cpus = 1
+	}
+	if systemDelta > 0 && cpuDelta > 0 {
+		info.CPUPct = cpuDelta / systemDelta * cpus * 100
+	}
+
+	// 與 docker stats 相同，扣除可回收的檔案快取
+	info.MemUsed = st.MemoryStats.Usage - st.MemoryStats.Stats["inactive_file"]
+	info.MemLimit = st.MemoryStats.Limit
+	return nil
+}
+
+type dockerCPUStats struct {
+	CPUUsage struct {
+		TotalUsage float64 `json:"total_usage"`
+	} `json:"cpu_usage"`
+	SystemUsage float64 `json:"system_cpu_usage"`
+	OnlineCPUs  float64 `json:"online_cpus"`
+}
+
+// 輪流顯示容器摘要與每個容器，最後一頁由主循環更新顯示
+// 按鈕切換到其他頁面時回傳 false
+func (s *screen) displayDockerPages(page int, summary dockerSummary) bool {
+	img := s.img
+	clearImage(img)
+	drawHeader(img, "Docker")
+	if compactLayout(img) {
+		drawText(img, 0, 16, fmt.Sprintf("Run %d Stp %d Bad %d", summary.Running, summary.Stopped, summary.Unhealthy))
+	} else if portraitLayout(img) {
+		drawText(img, 0, 16, fmt.Sprintf("Run  %3d", summary.Running))
+		drawText(img, 0, 27, fmt.Sprintf("Stp  %3d", summary.Stopped))
+		drawText(img, 0, 38, fmt.Sprintf("Bad  %3d", summary.Unhealthy))
+	} else {
+		drawText(img, 0, 16, fmt.Sprintf("Running   %3d", summary.Running))
+		drawText(img, 0, 27, fmt.Sprintf("Stopped   %3d", summary.Stopped))
+		drawText(img, 0, 38, fmt.Sprintf("Unhealthy %3d", summary.Unhealthy))
+	}
+	drawFooter(img)
+
+	for _, c := range summary.Containers {
+		if c.State != "running" && !c.Unhealthy {
+			continue
+		}
+		// 更新顯示
+		s.updateDisplay()
+		if !s.holdPage(page, s.pageTime()) {
+			return false
+		}
+
+		clearImage(img)
+		drawHeader(img, c.Name)
+		state := c.State
+		if c.Unhealthy {
+			state += " !"
+		}
+		if compactLayout(img) {
+			drawText(img, 0, 16, fmt.Sprintf("%s CPU %.1f%%", state, c.CPUPct))
+			continue
+		}
+		drawLines(img, []string{
+			state,
+			fmt.Sprintf("CPU %6.1f%%", c.CPUPct),
+			fmt.Sprintf("Mem %s/%s", formatBytesShort(c.MemUsed), formatBytesShort(c.MemLimit)),
+		})
+		drawFooter(img)
+	}
+	return true
+}
+
+// 取 .env 檔案中的 Docker 設定
+func shouldShowDocker() (bool, string) {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	showDockerStr := envConfig["SHOW_DOCKER"]
+	socket := envConfig["DOCKER_SOCKET"]
+	if socket == "" {
+		socket = "/var/run/docker.sock" // 預設值
+	}
+	return strings.ToLower(showDockerStr) == "true", socket
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// 在暫存的 Unix socket 上啟動假的 Docker Engine API
+func fakeDockerSocket(t *testing.T, handler http.Handler) string {
+	t.Helper()
+	// socket 路徑長度有限制，不使用 t.TempDir 的長路徑
+	dir, err := os.MkdirTemp("", "docker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "docker.sock")
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewUnstartedServer(handler)
+	srv.Listener = ln
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return socket
+}
+
+func TestDockerSummary(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /containers/json", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("all") != "1" {
+			t.Errorf("all = %q, want 1", r.URL.Query().Get("all"))
+		}
+		fmt.Fprint(w, `[
+			{"Id": "aaa", "Names": ["/web"], "State": "running", "Status": "Up 2 hours (healthy)"},
+			{"Id": "bbb", "Names": ["/db"], "State": "running", "Status": "Up 5 minutes (unhealthy)"},
+			{"Id": "ccc", "Names": ["/job"], "State": "exited", "Status": "Exited (0) 1 hour ago"}
+		]`)
+	})
+	mux.HandleFunc("GET /containers/aaa/stats", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("stream") != "false" {
+			t.Errorf("stream = %q, want false", r.URL.Query().Get("stream"))
+		}
+		fmt.Fprint(w, `{
+			"cpu_stats": {"cpu_usage": {"total_usage": 300}, "system_cpu_usage": 2000, "online_cpus": 4},
+			"precpu_stats": {"cpu_usage": {"total_usage": 100}, "system_cpu_usage": 1000},
+			"memory_stats": {"usage": 3000, "limit": 8000, "stats": {"inactive_file": 1000}}
+		}`)
+	})
+	mux.HandleFunc("GET /containers/bbb/stats", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "no such container", http.StatusNotFound)
+	})
+
+	summary, err := newDockerClient(fakeDockerSocket(t, mux)).summary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Running != 2 || summary.Stopped != 1 || summary.Unhealthy != 1 {
+		t.Errorf("summary = %d running, %d stopped, %d unhealthy, want 2, 1, 1",
+			summary.Running, summary.Stopped, summary.Unhealthy)
+	}
+	if len(summary.Containers) != 3 {
+		t.Fatalf("got %d containers, want 3", len(summary.Containers))
+	}
+
+	web := summary.Containers[0]
+	if web.Name != "web" || web.Unhealthy {
+		t.Errorf("web = %+v", web)
+	}
+	// (300-100)/(2000-1000) * 4 CPU * 100
+	if web.CPUPct != 80 {
+		t.Errorf("web CPU = %v, want 80", web.CPUPct)
+	}
+	if web.MemUsed != 2000 || web.MemLimit != 8000 {
+		t.Errorf("web memory = %v/%v, want 2000/8000", web.MemUsed, web.MemLimit)
+	}
+
+	// 讀取資源使用失敗時仍列出容器
+	db := summary.Containers[1]
+	if db.Name != "db" || !db.Unhealthy || db.CPUPct != 0 {
+		t.Errorf("db = %+v", db)
+	}
+}
+
+// 容器的 stats 同時讀取，並行數量不超過 dockerStatsWorkers
+func TestDockerSummaryConcurrentStats(t *testing.T) {
+	const n = dockerStatsWorkers + 3
+	var mu sync.Mutex
+	active, peak := 0, 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /containers/json", func(w http.ResponseWriter, _ *http.Request) {
+		items := make([]string, n)
+		for i := range items {
+			items[i] = fmt.Sprintf(`{"Id": "c%d", "Names": ["/c%d"], "State": "running", "Status": "Up"}`, i, i)
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(items, ","))
+	})
+	mux.HandleFunc("GET /containers/{id}/stats", func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		active++
+		peak = max(peak, active)
+		mu.Unlock()
+		time.Sleep(50 * time.Millisecond)
+		mu.Lock()
+		active--
+		mu.Unlock()
+		fmt.Fprint(w, `{"memory_stats": {"usage": 1000, "limit": 8000}}`)
+	})
+
+	summary, err := newDockerClient(fakeDockerSocket(t, mux)).summary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range summary.Containers {
+		if c.MemUsed != 1000 {
+			t.Errorf("%s memory = %v, want 1000", c.Name, c.MemUsed)
+		}
+	}
+	if peak < 2 || peak > dockerStatsWorkers {
+		t.Errorf("peak concurrent stats = %d, want 2..%d", peak, dockerStatsWorkers)
+	}
+}
+
+func TestDockerAPIError(t *testing.T) {
+	socket := fakeDockerSocket(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "permission denied", http.StatusForbidden)
+	}))
+	if _, err := newDockerClient(socket).summary(); err == nil {
+		t.Error("summary() error = nil, want 403 error")
+	}
+}
+
+func TestDockerSocketMissing(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "missing.sock")
+	if _, err := newDockerClient(socket).summary(); err == nil {
+		t.Error("summary() error = nil, want dial error")
+	}
+}
+
+func TestGetDockerClientReuse(t *testing.T) {
+	t.Cleanup(func() { sharedDockerClient = nil })
+	a := getDockerClient("/run/a.sock")
+	if b := getDockerClient("/run/a.sock"); b != a {
+		t.Error("same socket created a new client")
+	}
+	if c := getDockerClient("/run/b.sock"); c == a || c.socket != "/run/b.sock" {
+		t.Error("changed socket did not create a new client")
+	}
+}
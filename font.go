@@ -0,0 +1,294 @@
+// 字型：TrueType / OpenType 與 BDF 點陣字型，支援中文
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// 目前使用的字型，預設為 ASCII 的 7x13 點陣字型
+var textFace font.Face = basicfont.Face7x13
+
+// 多個顯示器同時繪製時保護 textFace，opentype 字型共用內部的緩衝區
+var textMutex sync.Mutex
+
+// 重新載入字型
+func reloadTextFace() {
+	face := loadTextFace()
+	textMutex.Lock()
+	textFace = face
+	textMutex.Unlock()
+}
+
+// 依照設定載入字型，失敗時使用預設字型
+func loadTextFace() font.Face {
+	path, size := fontConfig()
+	if path == "" {
+		return basicfont.Face7x13
+	}
+	face, err := loadFontFile(path, size)
+	if err != nil {
+		log.Printf("字型 %s 載入失敗，使用預設字型: %v", path, err)
+		return basicfont.Face7x13
+	}
+	log.Printf("使用字型 %s，高度 %d 像素\n", path, face.Metrics().Height.Ceil())
+	return face
+}
+
+// 依副檔名載入 TTF / OTF / TTC 或 BDF 字型，size 為像素大小
+func loadFontFile(path string, size float64) (font.Face, error) {
+	if strings.EqualFold(filepath.Ext(path), ".bdf") {
+		return loadBDF(path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f *opentype.Font
+	if strings.EqualFold(filepath.Ext(path), ".ttc") {
+		collection, err := opentype.ParseCollection(data)
+		if err != nil {
+			return nil, err
+		}
+		f, err = collection.Font(0)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		f, err = opentype.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// DPI 72 時，點數等於像素
+	return opentype.NewFace(f, &opentype.FaceOptions{
+		Size:    size,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+}
+
+// 文字基線距離頂端的像素，7x13 字型為 13
+func textBaseline() int {
+	textMutex.Lock()
+	defer textMutex.Unlock()
+	m := textFace.Metrics()
+	return (m.Ascent + m.Descent).Ceil()
+}
+
+// 文字實際繪製的像素寬度，中英文混合時也正確
+func textWidth(s string) int {
+	textMutex.Lock()
+	defer textMutex.Unlock()
+	return font.MeasureString(textFace, s).Ceil()
+}
+
+// 文字基線以下的像素
+func textDescent() int {
+	textMutex.Lock()
+	defer textMutex.Unlock()
+	return textFace.Metrics().Descent.Ceil()
+}
+
+// BDF 點陣字型的單一字元
+type bdfGlyph struct {
+	mask    *image.Alpha
+	advance int
+	xOff    int // 左側偏移
+	yOff    int // 基線以上的偏移 (BBX 的 y offset)
+}
+
+// BDF 點陣字型，例如 GNU Unifont
+type bdfFace struct {
+	glyphs   map[rune]*bdfGlyph
+	ascent   int
+	descent  int
+	fallback *bdfGlyph
+}
+
+// 解析 BDF 檔案
+func loadBDF(path string) (font.Face, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	face := &bdfFace{glyphs: make(map[rune]*bdfGlyph)}
+	defaultChar := rune(-1)
+	var (
+		g        *bdfGlyph
+		encoding rune
+		w, h     int
+		row      int
+		inBitmap bool
+		// STARTCHAR 之前的 DWIDTH 為所有字元預設的寬度 (BDF 2.2)
+		defaultAdvance int
+	)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if inBitmap {
+			if line == "ENDCHAR" {
+				inBitmap = false
+				if encoding >= 0 {
+					face.glyphs[encoding] = g
+				}
+				g = nil
+				continue
+			}
+			if row < h {
+				bits, err := hex.DecodeString(line)
+				if err != nil {
+					return nil, fmt.Errorf("bdf bitmap: %w", err)
+				}
+				for x := range w {
+					if x/8 < len(bits) && bits[x/8]&(0x80>>(x%8)) != 0 {
+						g.mask.Pix[row*g.mask.Stride+x] = 0xFF
+					}
+				}
+			}
+			row++
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "FONT_ASCENT":
+			face.ascent = atoiField(fields, 1)
+		case "FONT_DESCENT":
+			face.descent = atoiField(fields, 1)
+		case "DEFAULT_CHAR":
+			defaultChar = rune(atoiField(fields, 1))
+		case "STARTCHAR":
+			g = &bdfGlyph{advance: defaultAdvance}
+			encoding = -1
+		case "ENCODING":
+			encoding = rune(atoiField(fields, 1))
+		case "DWIDTH":
+			if g == nil {
+				defaultAdvance = atoiField(fields, 1)
+				continue
+			}
+			g.advance = atoiField(fields, 1)
+		case "BBX":
+			if g == nil {
+				continue
+			}
+			w, h = atoiField(fields, 1), atoiField(fields, 2)
+			g.xOff, g.yOff = atoiField(fields, 3), atoiField(fields, 4)
+		case "BITMAP":
+			if g == nil {
+				continue
+			}
+			g.mask = image.NewAlpha(image.Rect(0, 0, w, h))
+			if g.advance == 0 {
+				g.advance = w
+			}
+			row = 0
+			inBitmap = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(face.glyphs) == 0 {
+		return nil, fmt.Errorf("bdf: no glyphs in %s", path)
+	}
+
+	face.fallback = face.glyphs[defaultChar]
+	if face.fallback == nil {
+		face.fallback = face.glyphs['?']
+	}
+	return face, nil
+}
+
+// 讀取欄位整數，缺少或錯誤時為 0
+func atoiField(fields []string, i int) int {
+	if i >= len(fields) {
+		return 0
+	}
+	v, _ := strconv.Atoi(fields[i])
+	return v
+}
+
+// 找不到字元時使用預設字元，避免文字被略過
+func (f *bdfFace) glyph(r rune) (*bdfGlyph, bool) {
+	if g, ok := f.glyphs[r]; ok {
+		return g, true
+	}
+	return f.fallback, f.fallback != nil
+}
+
+func (f *bdfFace) Close() error { return nil }
+
+func (f *bdfFace) Kern(r0, r1 rune) fixed.Int26_6 { return 0 }
+
+func (f *bdfFace) Metrics() font.Metrics {
+	return font.Metrics{
+		Height:     fixed.I(f.ascent + f.descent),
+		Ascent:     fixed.I(f.ascent),
+		Descent:    fixed.I(f.descent),
+		XHeight:    fixed.I(f.ascent),
+		CapHeight:  fixed.I(f.ascent),
+		CaretSlope: image.Point{X: 0, Y: 1},
+	}
+}
+
+func (f *bdfFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	g, ok := f.glyph(r)
+	if g == nil || g.mask == nil {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	size := g.mask.Bounds().Size()
+	x := dot.X.Round() + g.xOff
+	y := dot.Y.Round() - g.yOff - size.Y
+	dr := image.Rect(x, y, x+size.X, y+size.Y)
+	return dr, g.mask, image.Point{}, fixed.I(g.advance), ok
+}
+
+func (f *bdfFace) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	g, ok := f.glyph(r)
+	if g == nil || g.mask == nil {
+		return fixed.Rectangle26_6{}, 0, false
+	}
+	size := g.mask.Bounds().Size()
+	bounds := fixed.R(g.xOff, -g.yOff-size.Y, g.xOff+size.X, -g.yOff)
+	return bounds, fixed.I(g.advance), ok
+}
+
+func (f *bdfFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	g, ok := f.glyph(r)
+	if g == nil {
+		return 0, false
+	}
+	return fixed.I(g.advance), ok
+}
+
+// 取 .env 檔案中的 FONT_FILE、FONT_SIZE 設定
+func fontConfig() (string, float64) {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	size, err := strconv.ParseFloat(envConfig["FONT_SIZE"], 64)
+	if err != nil || size <= 0 {
+		size = 13 // 預設值
+	}
+	return strings.TrimSpace(envConfig["FONT_FILE"]), size
+}
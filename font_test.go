@@ -0,0 +1,125 @@
+package main
+
+import (
+	"image"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+)
+
+func writeBDF(t *testing.T, src string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.bdf")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadBDF(t *testing.T) {
+	path := writeBDF(t, `STARTFONT 2.1
+FONT test
+SIZE 8 75 75
+FONTBOUNDINGBOX 4 4 0 -1
+FONT_ASCENT 3
+FONT_DESCENT 1
+DEFAULT_CHAR 63
+CHARS 2
+STARTCHAR A
+ENCODING 65
+DWIDTH 5 0
+BBX 3 4 0 -1
+BITMAP
+40
+A0
+E0
+A0
+ENDCHAR
+STARTCHAR question
+ENCODING 63
+DWIDTH 4 0
+BBX 2 2 1 1
+BITMAP
+C0
+40
+ENDCHAR
+ENDFONT
+`)
+	face, err := loadBDF(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := face.Metrics()
+	if m.Ascent != fixed.I(3) || m.Descent != fixed.I(1) {
+		t.Errorf("metrics ascent %v descent %v, want 3 and 1", m.Ascent, m.Descent)
+	}
+
+	dr, mask, _, advance, ok := face.Glyph(fixed.P(10, 20), 'A')
+	if !ok || advance != fixed.I(5) {
+		t.Fatalf("Glyph('A') ok %v advance %v, want true and 5", ok, advance)
+	}
+	// BBX y offset -1：字元底部在基線下 1 像素
+	if want := image.Rect(10, 17, 13, 21); dr != want {
+		t.Errorf("Glyph('A') rect %v, want %v", dr, want)
+	}
+	rows := []string{".#.", "#.#", "###", "#.#"}
+	for y, row := range rows {
+		for x, c := range row {
+			_, _, _, a := mask.At(x, y).RGBA()
+			if (a != 0) != (c == '#') {
+				t.Errorf("A pixel (%d,%d) = %v, want %c", x, y, a != 0, c)
+			}
+		}
+	}
+
+	// 沒有的字元使用 DEFAULT_CHAR
+	if adv, ok := face.GlyphAdvance('Z'); !ok || adv != fixed.I(4) {
+		t.Errorf("GlyphAdvance('Z') = %v %v, want default char advance 4", adv, ok)
+	}
+}
+
+// BDF 2.2 允許在 STARTCHAR 之前設定所有字元共用的 DWIDTH
+func TestLoadBDFGlobalDWIDTH(t *testing.T) {
+	path := writeBDF(t, `STARTFONT 2.2
+FONT test
+SIZE 8 75 75
+FONTBOUNDINGBOX 8 8 0 0
+FONT_ASCENT 8
+FONT_DESCENT 0
+DWIDTH 6 0
+CHARS 2
+STARTCHAR a
+ENCODING 97
+BBX 1 1 0 0
+BITMAP
+80
+ENDCHAR
+STARTCHAR b
+ENCODING 98
+DWIDTH 9 0
+BBX 1 1 0 0
+BITMAP
+80
+ENDCHAR
+ENDFONT
+`)
+	face, err := loadBDF(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if adv, _ := face.GlyphAdvance('a'); adv != fixed.I(6) {
+		t.Errorf("a advance = %v, want global DWIDTH 6", adv)
+	}
+	if adv, _ := face.GlyphAdvance('b'); adv != fixed.I(9) {
+		t.Errorf("b advance = %v, want its own DWIDTH 9", adv)
+	}
+}
+
+func TestLoadBDFNoGlyphs(t *testing.T) {
+	path := writeBDF(t, "STARTFONT 2.1\nDWIDTH 6 0\nBBX 1 1 0 0\nBITMAP\nENDFONT\n")
+	if _, err := loadBDF(path); err == nil {
+		t.Error("loadBDF() error = nil, want no glyphs error")
+	}
+}
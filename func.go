@@ -3,6 +3,7 @@ package main
 
 import (
 	"bufio"
+	"fmt"
 	"image"
 	"log"
 	"math"
@@ -18,33 +19,28 @@ import (
 	"github.com/fsnotify/fsnotify"
 	"github.com/joho/godotenv"
 	"golang.org/x/image/font"
-	"golang.org/x/image/font/basicfont"
 	"golang.org/x/image/math/fixed"
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpioreg"
-	"periph.io/x/devices/v3/ssd1306"
 	"periph.io/x/devices/v3/ssd1306/image1bit"
 )
 
-func showBMP(imageData [][]byte, dev *ssd1306.Dev, img *image1bit.VerticalLSB, bounds image.Rectangle, sleepTime time.Duration) {
-	for _, frameData := range imageData {
-		if len(frameData) <= len(img.Pix) {
-			copy(img.Pix[:len(frameData)], frameData)
-			if err := dev.Draw(bounds, img, image.Point{}); err != nil {
-				log.Fatal(err)
-			}
-			// 這裡可以添加一個小的延遲，以控制動畫的速度
-			time.Sleep(time.Millisecond * 100) // 例如，每幀延遲 100 毫秒
-		} else {
+// 依序顯示每一幀，delays 為每幀的延遲時間，未指定時每幀延遲 100 毫秒
+func showBMP(imageData [][]byte, delays []time.Duration, dev *diffDisplay, img *image1bit.VerticalLSB, bounds image.Rectangle) {
+	for i, frameData := range imageData {
+		if len(frameData) > len(img.Pix) {
 			log.Printf("幀資料長度 (%d) 大於螢幕緩衝區長度 (%d)，可能會截斷", len(frameData), len(img.Pix))
-			copy(img.Pix, frameData)
-			if err := dev.Draw(bounds, img, image.Point{}); err != nil {
-				log.Fatal(err)
-			}
-			_ = sleepTime
-			// time.Sleep(time.Millisecond * 100)
 		}
-		// 如果您希望所有幀快速連續顯示而不停頓，則不需要 time.Sleep()
+		copy(img.Pix, frameData)
+		if err := dev.Draw(bounds, img, image.Point{}); err != nil {
+			log.Fatal(err)
+		}
+		// 控制動畫的速度，GIF 延遲為 0 時同樣使用預設值
+		delay := 100 * time.Millisecond
+		if i < len(delays) && delays[i] > 0 {
+			delay = delays[i]
+		}
+		time.Sleep(delay)
 	}
 }
 
@@ -138,44 +134,6 @@ func getCPUUsage() float64 {
 	return cpuUsage
 }
 
-// 獲取 RAM 使用率
-func getRAMUsage() (float64, float64, float64) {
-	memInfo, err := os.Open("/proc/meminfo")
-	if err != nil {
-		return 0.0, 0.0, 0.0
-	}
-	defer memInfo.Close()
-
-	reader := bufio.NewReader(memInfo)
-	var totalRAM float64
-	var availableRAM float64
-
-	for {
-		line, err := reader.ReadString('\n')
-		if err != nil {
-			break
-		}
-		fields := strings.Split(line, ":")
-		if len(fields) == 2 {
-			key := strings.TrimSpace(fields[0])
-			valueStr := strings.TrimSpace(strings.ReplaceAll(fields[1], " kB", ""))
-			value, err := strconv.ParseFloat(valueStr, 64)
-			if err != nil {
-				continue
-			}
-			switch key {
-			case "MemTotal":
-				totalRAM = value / 1024 / 1024 // GB
-			case "MemAvailable":
-				availableRAM = value / 1024 / 1024 // GB
-			}
-		}
-	}
-	usedRAM := totalRAM - availableRAM
-	usagePct := float64(usedRAM) / float64(totalRAM) * 100
-	return totalRAM, usedRAM, usagePct
-}
-
 // 獲取 CPU 溫度
 func getCPUTemperature() float64 {
 	content, err := os.ReadFile("/sys/class/thermal/thermal_zone0/temp")
@@ -203,53 +161,36 @@ func getDiskSpace() (float64, float64, float64, float64) {
 	return total, free, used, usagePct
 }
 
-// 清空畫面
+// 清空畫面，直接清除整個位元組陣列
 func clearImage(img *image1bit.VerticalLSB) {
-	for y := range img.Bounds().Dy() {
-		for x := range img.Bounds().Dx() {
-			img.Set(x, y, image1bit.Off)
-		}
-	}
+	clear(img.Pix)
 }
 
-// 繪製文字
+// 繪製文字，只轉換文字範圍內的像素
 func drawText(img *image1bit.VerticalLSB, x, y int, text string) {
-	// 創建一個新的圖像作為文字源
-	textImg := image.NewRGBA(image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy()))
-	d := &font.Drawer{
-		Dst:  textImg,
-		Src:  image.White,
-		Face: basicfont.Face7x13,
-		Dot:  fixed.P(x, y+13), // +13 因為字體高度是13
-	}
-	d.DrawString(text)
-
-	// 將文字圖像轉換到我們的1bit圖像
-	for ty := range textImg.Bounds().Dy() {
-		for tx := range textImg.Bounds().Dx() {
-			r, _, _, _ := textImg.At(tx, ty).RGBA()
-			if r > 0xFF00 { // 如果像素是亮的
-				img.Set(tx, ty, image1bit.On)
-			}
-		}
-	}
+	newCanvas(img).text(x, y, text)
 }
 
 // 繪製放大的文字 (簡化方法)
 func drawLargeText(img *image1bit.VerticalLSB, x, y int, text string, scale int) {
+	height := textBaseline()
 	for _, r := range text {
-		charImg := image1bit.NewVerticalLSB(image.Rect(0, 0, 7, 13)) // basicfont 的尺寸
+		// 每個字元依實際寬度繪製，全形字元較寬
+		width := max(textWidth(string(r)), 1)
+		charImg := image1bit.NewVerticalLSB(image.Rect(0, 0, width, height))
+		textMutex.Lock()
 		d := font.Drawer{
 			Dst:  charImg,
 			Src:  image.White,
-			Face: basicfont.Face7x13,
-			Dot:  fixed.P(0, 13),
+			Face: textFace,
+			Dot:  fixed.P(0, height),
 		}
 		d.DrawString(string(r))
+		textMutex.Unlock()
 
 		// 將字元圖像放大並繪製到主圖像
-		for cy := range 13 {
-			for cx := range 7 {
+		for cy := range height {
+			for cx := range width {
 				if charImg.BitAt(cx, cy) == image1bit.On {
 					for ly := range scale {
 						for lx := range scale {
@@ -263,36 +204,25 @@ func drawLargeText(img *image1bit.VerticalLSB, x, y int, text string, scale int)
 				}
 			}
 		}
-		x += 7 // basicfont 的寬度
+		x += width
 	}
 }
 
-func displayPagedError(dev *ssd1306.Dev, img *image1bit.VerticalLSB, lines []string) {
-	const linesPerPage = 3
+// 顯示錯誤訊息，三行內依畫面寬度自動換行，更長的訊息以跑馬燈捲動
+func (s *screen) displayError(message string) {
+	const maxLines = 3
 	const lineHeight = 16
 
-	for i := 0; i < len(lines); i += linesPerPage {
-		clearImage(img)
-		// end := i + linesPerPage
-		// if end > len(lines) {
-		// 	end = len(lines)
-		// }
-		end := min(i+linesPerPage, len(lines))
+	img := s.img
+	drawHeader(img, "Error")
 
-		drawText(img, 2, 0, testCenter("Error", 18))
-		drawText(img, 0, 3, "___________________")
-
-		for j, line := range lines[i:end] {
-			drawText(img, 0, lineHeight*(j+1), line)
-		}
-		// 更新顯示
-		if err := dev.Draw(dev.Bounds(), img, image.Point{}); err != nil {
-			log.Fatal(err)
-		}
-
-		if end < len(lines) {
-			time.Sleep(5 * time.Second)
-		}
+	lines := wrapText(message, img.Bounds().Dx())
+	if len(lines) > maxLines {
+		s.drawMarquee(0, img.Bounds().Dx(), 27, message, alignLeft)
+		return
+	}
+	for i, line := range lines {
+		drawText(img, 0, lineHeight*(i+1), line)
 	}
 }
 
@@ -322,24 +252,31 @@ func shouldShowDHT() (bool, string, string) {
 	return strings.ToLower(showDHTStr) == "true", DHTTypeStr, DHTPinStr
 }
 
-func shouldShowLOGO() bool {
+// 取 .env 檔案中的 SHOW_LOGO 設定，true 使用內建 LOGO，其他值視為圖片檔案路徑
+func shouldShowLOGO() (bool, string) {
 	configMutex.RLock()
 	defer configMutex.RUnlock()
-	showLogoStr := envConfig["SHOW_LOGO"]
-	return strings.ToLower(showLogoStr) == "true"
+	showLogoStr := strings.TrimSpace(envConfig["SHOW_LOGO"])
+	switch strings.ToLower(showLogoStr) {
+	case "true":
+		return true, ""
+	case "", "false":
+		return false, ""
+	}
+	return true, showLogoStr
 }
 
-func shouldOnLoop() bool {
+func shouldOnLoop(prefix string) bool {
 	configMutex.RLock()
 	defer configMutex.RUnlock()
-	onLoopStr := envConfig["ON_LOOP"]
+	onLoopStr := screenEnv(prefix, "ON_LOOP")
 	return strings.ToLower(onLoopStr) == "true"
 }
 
-func defaultPage() int {
+func defaultPage(prefix string) int {
 	configMutex.RLock()
 	defer configMutex.RUnlock()
-	defaultPageStr := envConfig["DEFAULT_PAGE"]
+	defaultPageStr := screenEnv(prefix, "DEFAULT_PAGE")
 	if defaultPageStr == "" {
 		defaultPageStr = "0" // 預設值
 	}
@@ -354,10 +291,10 @@ func defaultPage() int {
 	return stepBy
 }
 
-func showSleepTime() time.Duration {
+func showSleepTime(prefix string) time.Duration {
 	configMutex.RLock()
 	defer configMutex.RUnlock()
-	showSleep := envConfig["SLEEP_TIME"]
+	showSleep := screenEnv(prefix, "SLEEP_TIME")
 	if showSleep == "" {
 		showSleep = "3" // 預設值
 	}
@@ -370,60 +307,34 @@ func showSleepTime() time.Duration {
 	return time.Duration(timeSleep) * time.Second
 }
 
-// 取 .env 檔案中的 GPIO_BUTTON1 設定
-func button1PinName() string {
+// 取 .env 檔案中的 GPIO_BUTTON1 ~ GPIO_BUTTON4 設定
+// 第一個顯示器以外的按鈕需要以前綴設定，例如 NET_GPIO_BUTTON1，沒有預設值
+func buttonPinName(prefix string, n int, primary bool) string {
 	configMutex.RLock()
 	defer configMutex.RUnlock()
-	pinName := envConfig["GPIO_BUTTON1"]
-	if pinName == "" {
-		pinName = "GPIO17" // 預設值
+	key := fmt.Sprintf("GPIO_BUTTON%d", n)
+	if !primary {
+		return envConfig[prefix+key]
 	}
-	return pinName
-}
-
-// 取 .env 檔案中的 GPIO_BUTTON2 設定
-func button2PinName() string {
-	configMutex.RLock()
-	defer configMutex.RUnlock()
-	pinName := envConfig["GPIO_BUTTON2"]
+	pinName := screenEnv(prefix, key)
 	if pinName == "" {
-		pinName = "GPIO27" // 預設值
+		pinName = []string{"GPIO17", "GPIO27", "GPIO22", "GPIO23"}[n-1] // 預設值
 	}
 	return pinName
 }
 
-// 取 .env 檔案中的 GPIO_BUTTON3 設定
-func button3PinName() string {
-	configMutex.RLock()
-	defer configMutex.RUnlock()
-	pinName := envConfig["GPIO_BUTTON3"]
-	if pinName == "" {
-		pinName = "GPIO22" // 預設值
-	}
-	return pinName
-}
-
-// 取 .env 檔案中的 GPIO_BUTTON4 設定
-func button4PinName() string {
+// 取 .env 檔案中的 BUTTON_PAGE 設定
+func setButtonPage(prefix string) int {
 	configMutex.RLock()
 	defer configMutex.RUnlock()
-	pinName := envConfig["GPIO_BUTTON4"]
-	if pinName == "" {
-		pinName = "GPIO23" // 預設值
-	}
-	return pinName
-}
-
-// 取 .env 檔案中的 BUTTON_PAGE 設定
-func setButtonPage() int {
-	pageStr := envConfig["BUTTON_PAGE"]
+	pageStr := screenEnv(prefix, "BUTTON_PAGE")
 	pageStrInt, err := strconv.Atoi(pageStr)
 	if err != nil {
 		log.Println("Error converting DEFAULT_PAGE to int:", err)
 		return 4 // 預設值
 	}
-	if pageStrInt > stepEnd {
-		return stepEnd // 預設值
+	if end := pageCount(); pageStrInt > end {
+		return end // 預設值
 	}
 	if pageStrInt <= 0 {
 		return 1
@@ -456,6 +367,7 @@ func monitorEnvFile() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	layoutWatch := watchLayout(watcher, "")
 
 	for {
 		select {
@@ -463,6 +375,29 @@ func monitorEnvFile() {
 			if !ok {
 				return
 			}
+			// 版面檔案變更時只重新載入版面
+			if layoutWatch != "" && event.Name == layoutWatch {
+				if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+					// 編輯器以取代檔案的方式存檔時，需要重新監控
+					time.Sleep(500 * time.Millisecond)
+					layoutWatch = watchLayout(watcher, layoutWatch)
+				} else if event.Op&fsnotify.Write != fsnotify.Write {
+					continue
+				}
+				now := time.Now()
+				if event.Name == lastWriteFile && now.Sub(lastWriteTime) < 1000*time.Millisecond {
+					continue
+				}
+				log.Println("Layout file modified, reloading layout.")
+
+				// 增加延遲以確保檔案完全寫入
+				time.Sleep(500 * time.Millisecond)
+				reloadLayout()
+
+				lastWriteTime = now
+				lastWriteFile = event.Name
+				continue
+			}
 			if event.Op&fsnotify.Write == fsnotify.Write {
 				now := time.Now()
 				if event.Name == lastWriteFile && now.Sub(lastWriteTime) < 1000*time.Millisecond {
@@ -480,20 +415,24 @@ func monitorEnvFile() {
 				envConfig = loadEnv()
 				configMutex.Unlock()
 
-				showLOGO = shouldShowLOGO()
+				showLOGO, logoPath = shouldShowLOGO()
+				reloadTextFace()
 				showDHT, DHTType, DHTPin = shouldShowDHT()
-				onLoop = shouldOnLoop()
-				stepBy = defaultPage()
-				// 每次循環延遲時間
-				sleepTime = showSleepTime()
-				originalSleep = sleepTime
-
-				// GPIO 按鈕、LED
-				button1Pin = gpioreg.ByName(button1PinName())
-				button2Pin = gpioreg.ByName(button2PinName())
-				button3Pin = gpioreg.ByName(button3PinName())
-				button4Pin = gpioreg.ByName(button4PinName())
-				buttonPage = setButtonPage()
+				showStorage, nvmeDevice, smartctlCmd, sdDevice = shouldShowStorage()
+				storageTempAlert, storageWearAlert = storageAlertLimits()
+				showSystemd, systemdUnits = shouldShowSystemd()
+				showDocker, dockerSocket = shouldShowDocker()
+				showProbes = shouldShowProbes()
+				showClock = shouldShowClock()
+				clockLoc = clockLocation()
+				reloadLayout()
+				layoutWatch = watchLayout(watcher, layoutWatch)
+				// 各顯示器的頁面、切換時間與按鈕，DISPLAYS 變更需要重新啟動
+				for _, s := range screens {
+					s.loadConfig()
+				}
+
+				// GPIO LED
 				led1Pin = gpioreg.ByName(led1PinName())
 				// 初始化 GPIO 按鈕和 LED
 				initGPIO()
@@ -513,6 +452,22 @@ func monitorEnvFile() {
 	}
 }
 
+// 監控 LAYOUT_FILE 設定的版面檔案，回傳目前監控的路徑
+func watchLayout(watcher *fsnotify.Watcher, old string) string {
+	if old != "" {
+		watcher.Remove(old)
+	}
+	path := layoutPath()
+	if path == "" {
+		return ""
+	}
+	if err := watcher.Add(path); err != nil {
+		log.Printf("無法監控版面檔案 %s: %v", path, err)
+		return ""
+	}
+	return path
+}
+
 func printEnvConfig(config map[string]string) {
 	for key, value := range config {
 		log.Printf("%s:%s\n", key, value)
@@ -555,7 +510,7 @@ func initGPIO() {
 	// 例如，設置引腳模式、配置中斷等
 	// 將 LED 引腳設置為輸出
 	ledStateMutex.Lock()
-	if onLoop {
+	if screens[0].looping() {
 		if err := led1Pin.Out(gpio.High); err != nil {
 			log.Fatalf("Failed to set LED pin %s as output: %v", led1Pin, err)
 		}
@@ -571,92 +526,105 @@ func initGPIO() {
 	pull := gpio.PullUp
 	edge := gpio.FallingEdge // 假設按下是下降沿
 
-	if err := button1Pin.In(pull, edge); err != nil {
-		log.Fatalf("Failed to set button 1 pin %s as input with pull-up and falling edge detection: %v", button1Pin, err)
-	}
-	log.Printf("Button 1 input on pin %s\n", button1Pin)
-
-	if err := button2Pin.In(gpio.PullUp, gpio.FallingEdge); err != nil {
-		log.Fatalf("Failed to set button 2 pin %s as input with pull-up and falling edge detection: %v", button2Pin, err)
-	}
-	log.Printf("Button 2 input on pin %s\n", button2Pin)
-
-	if err := button3Pin.In(gpio.PullUp, gpio.FallingEdge); err != nil {
-		log.Fatalf("Failed to set button 3 pin %s as input with pull-up and falling edge detection: %v", button3Pin, err)
-	}
-	log.Printf("Button 3 input on pin %s\n", button3Pin)
-
-	if err := button4Pin.In(gpio.PullUp, gpio.FallingEdge); err != nil {
-		log.Fatalf("Failed to set button 4 pin %s as input with pull-up and falling edge detection: %v", button4Pin, err)
+	for _, s := range screens {
+		for i, pin := range s.buttonPins() {
+			if pin == nil {
+				continue
+			}
+			if err := pin.In(pull, edge); err != nil {
+				log.Fatalf("Failed to set %s button %d pin %s as input with pull-up and falling edge detection: %v", s, i+1, pin, err)
+			}
+			log.Printf("%s button %d input on pin %s\n", s, i+1, pin)
+		}
 	}
-	log.Printf("Button 4 input on pin %s\n", button4Pin)
-
 }
 
-// 等待按鈕按下事件
-func waitForButtonPress(pin gpio.PinIO, buttonName string) {
+// 等待按鈕按下事件，n 為 1 ~ 4
+func waitForButtonPress(s *screen, n int) {
+	pin := s.buttonPins()[n-1]
+	buttonName := fmt.Sprintf("Button %d", n)
+	if !s.primary {
+		buttonName = s.name + " " + buttonName
+	}
 	for {
 		pin.WaitForEdge(-1 * time.Second) // 等待邊緣觸發 (按下或釋放)
 		time.Sleep(50 * time.Millisecond) // 簡單的防彈跳延遲
 		if !pin.Read() {                  // 檢查是否為按下狀態 (假設按下為 Low)
 			log.Printf("%s 按下，", buttonName)
-			// 在這裡直接處理按鈕按下的事件
-			switch buttonName {
-			case "Button 1":
-				// 處理 Button 1 的事件
-				if stepBy <= 1 {
-					stepBy = stepEnd
-				} else {
-					stepBy--
-				}
-				log.Println("上一頁：", stepBy)
-				stopLoop()
-
-			case "Button 2":
-				// 處理 Button 2 的事件
-				if stepBy >= stepEnd {
-					stepBy = 1
-				} else {
-					stepBy++
-				}
-				log.Println("下一頁：", stepBy)
-				stopLoop()
-
-			case "Button 3":
-				// 處理 Button 3 的事件
-				stepBy = buttonPage
-				log.Println("跳到：", buttonPage, " 頁")
-				stopLoop()
-
-			case "Button 4":
-				// 處理 Button 4 的事件
-				onLoop = !onLoop
-				if onLoop {
-					sleepTime = originalSleep
-					ledStateMutex.Lock()
-					if err := led1Pin.Out(gpio.High); err != nil {
-						log.Fatalf("Failed to set LED pin %s as output: %v", led1Pin, err)
-					}
-					ledStateMutex.Unlock()
-					log.Printf("LED pin %s 點亮 循環：%v\n", led1Pin, onLoop)
-				} else {
-					stopLoop()
-				}
+			s.handlePress(n)
+			time.Sleep(200 * time.Millisecond) // 避免快速重複觸發
+		}
+	}
+}
 
+// 處理按鈕 n 按下的事件，休眠中只喚醒顯示器
+func (s *screen) handlePress(n int) {
+	// 先記錄按下並讀取休眠狀態，之後才等待組合鍵
+	if s.press() {
+		log.Printf("%s 喚醒", s)
+		return
+	}
+	if s.screenshotCombo(n) {
+		return
+	}
+	// 在這裡直接處理按鈕按下的事件
+	switch n {
+	case 1:
+		// 處理 Button 1 的事件
+		log.Println("上一頁：", s.step(-1))
+		s.stopLoop()
+
+	case 2:
+		// 處理 Button 2 的事件
+		log.Println("下一頁：", s.step(1))
+		s.stopLoop()
+
+	case 3:
+		// 處理 Button 3 的事件
+		log.Println("跳到：", s.jumpToButtonPage(), " 頁")
+		s.stopLoop()
+
+	case 4:
+		// 處理 Button 4 的事件
+		if s.toggleLoop() {
+			if s.primary {
+				ledStateMutex.Lock()
+				if err := led1Pin.Out(gpio.High); err != nil {
+					log.Fatalf("Failed to set LED pin %s as output: %v", led1Pin, err)
+				}
+				ledStateMutex.Unlock()
+				log.Printf("LED pin %s 點亮 循環：%v\n", led1Pin, true)
 			}
-			time.Sleep(200 * time.Millisecond) // 避免快速重複觸發
+		} else {
+			s.stopLoop()
 		}
 	}
 }
 
-// 停止循環顯示
-func stopLoop() {
+// 停止循環顯示，第一個顯示器同時熄滅 LED
+func (s *screen) stopLoop() {
+	s.mu.Lock()
+	s.sleepTime = 1 * time.Second
+	s.onLoop = false
+	s.mu.Unlock()
+	if !s.primary {
+		return
+	}
 	ledStateMutex.Lock()
-	sleepTime = 1 * time.Second
 	if err := led1Pin.Out(gpio.Low); err != nil {
 		log.Fatalf("Failed to set LED pin %s as output: %v", led1Pin, err)
 	}
 	ledStateMutex.Unlock()
-	onLoop = false
-	log.Printf("LED pin %s 熄滅 循環：%v\n", led1Pin, onLoop)
+	log.Printf("LED pin %s 熄滅 循環：%v\n", led1Pin, false)
+}
+
+// 切換循環顯示，開始循環時恢復每頁停留的時間，回傳是否開始循環
+func (s *screen) toggleLoop() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onLoop = !s.onLoop
+	if s.onLoop {
+		s.sleepTime = s.originalSleep
+	}
+	return s.onLoop
 }
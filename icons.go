@@ -0,0 +1,535 @@
+// 單色圖示：內建 8x8 與 16x16 圖示（其他大小由這兩組放大）與 ICON_DIR 目錄中的 PNG 自訂圖示
+package main
+
+import (
+	"fmt"
+	"image"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+// 內建 8x8 圖示，# 為亮、. 為暗
+var builtinIcons = map[string][]string{
+	"thermometer": {
+		"...##...",
+		"..#..#..",
+		"..#..#..",
+		"..#..#..",
+		"..####..",
+		".######.",
+		".######.",
+		"..####..",
+	},
+	"droplet": {
+		"...##...",
+		"...##...",
+		"..####..",
+		".######.",
+		".######.",
+		"#####.##",
+		".####.#.",
+		"..####..",
+	},
+	"cpu": {
+		"..#..#..",
+		".######.",
+		"##....##",
+		".#.##.#.",
+		".#.##.#.",
+		"##....##",
+		".######.",
+		"..#..#..",
+	},
+	"ram": {
+		"........",
+		"########",
+		"#......#",
+		"#.##.#.#",
+		"#.##.#.#",
+		"########",
+		".#.#.#.#",
+		"........",
+	},
+	"disk": {
+		".######.",
+		"#......#",
+		"#......#",
+		"#......#",
+		"########",
+		"#......#",
+		"#....#.#",
+		".######.",
+	},
+	"wifi0": {
+		"........",
+		"........",
+		"........",
+		"........",
+		"........",
+		"........",
+		"........",
+		"...##...",
+	},
+	"wifi1": {
+		"........",
+		"........",
+		"........",
+		"........",
+		"...##...",
+		"..#..#..",
+		"........",
+		"...##...",
+	},
+	"wifi2": {
+		"........",
+		"........",
+		"..####..",
+		".#....#.",
+		"...##...",
+		"..#..#..",
+		"........",
+		"...##...",
+	},
+	"wifi3": {
+		".######.",
+		"#......#",
+		"..####..",
+		".#....#.",
+		"...##...",
+		"..#..#..",
+		"........",
+		"...##...",
+	},
+	"ethernet": {
+		"..####..",
+		".##..##.",
+		"#......#",
+		"#......#",
+		"#.#..#.#",
+		"#.#..#.#",
+		"#......#",
+		"########",
+	},
+	"warning": {
+		"...##...",
+		"...##...",
+		"..#..#..",
+		"..#..#..",
+		".#.##.#.",
+		".#....#.",
+		"#..##..#",
+		"########",
+	},
+	"fan": {
+		".##.....",
+		".###..#.",
+		"..##.##.",
+		"...##...",
+		"...##...",
+		".##.##..",
+		".#..###.",
+		".....##.",
+	},
+	"clock": {
+		"..####..",
+		".#....#.",
+		"#..#...#",
+		"#..#...#",
+		"#..###.#",
+		"#......#",
+		".#....#.",
+		"..####..",
+	},
+	"check": {
+		"........",
+		".......#",
+		"......#.",
+		".....#..",
+		"#...#...",
+		".#.#....",
+		"..#.....",
+		"........",
+	},
+	"celsius": {
+		".#......",
+		"#.#.....",
+		".#..###.",
+		"...#...#",
+		"...#....",
+		"...#....",
+		"...#...#",
+		"....###.",
+	},
+}
+
+// 內建 16x16 圖示，名稱與 8x8 圖示相同，另外繪製而不是由 8x8 放大
+var builtinIcons16 = map[string][]string{
+	"thermometer": {
+		".......##.......",
+		"......#..#......",
+		"......#..#.##...",
+		"......#..#......",
+		"......#..#.##...",
+		"......#..#......",
+		"......#..#.##...",
+		"......####......",
+		"......####......",
+		"......####......",
+		".....######.....",
+		"....########....",
+		"....########....",
+		"....########....",
+		".....######.....",
+		"......####......",
+	},
+	"droplet": {
+		".......##.......",
+		".......##.......",
+		"......####......",
+		"......####......",
+		".....######.....",
+		"....########....",
+		"...##########...",
+		"..############..",
+		"..############..",
+		".#########.####.",
+		".#########.####.",
+		".#########.####.",
+		"..#######.####..",
+		"...##########...",
+		"....########....",
+		"......####......",
+	},
+	"cpu": {
+		"....#.#..#.#....",
+		"....#.#..#.#....",
+		"..############..",
+		"..#..........#..",
+		"###..........###",
+		"..#..######..#..",
+		"###..#....#..###",
+		"..#..#....#..#..",
+		"..#..#....#..#..",
+		"###..#....#..###",
+		"..#..######..#..",
+		"###..........###",
+		"..#..........#..",
+		"..############..",
+		"....#.#..#.#....",
+		"....#.#..#.#....",
+	},
+	"ram": {
+		"................",
+		"................",
+		"................",
+		"################",
+		"#..............#",
+		"#.##.##..##.##.#",
+		"#.##.##..##.##.#",
+		"#.##.##..##.##.#",
+		"#..............#",
+		"################",
+		"#.#.#.#..#.#.#.#",
+		"#.#.#.#..#.#.#.#",
+		"................",
+		"................",
+		"................",
+		"................",
+	},
+	"disk": {
+		"................",
+		"..############..",
+		".#............#.",
+		"#..............#",
+		"#..............#",
+		"#..............#",
+		"#..............#",
+		"#..............#",
+		"################",
+		"#..............#",
+		"#..............#",
+		"#.........###..#",
+		"#..............#",
+		".#............#.",
+		"..############..",
+		"................",
+	},
+	"wifi0": {
+		"................",
+		"................",
+		"................",
+		"................",
+		"................",
+		"................",
+		"................",
+		"................",
+		"................",
+		"................",
+		"................",
+		"................",
+		".......##.......",
+		".......##.......",
+		"................",
+		"................",
+	},
+	"wifi1": {
+		"................",
+		"................",
+		"................",
+		"................",
+		"................",
+		"................",
+		"................",
+		"................",
+		"......####......",
+		"....##....##....",
+		"...#........#...",
+		"................",
+		".......##.......",
+		".......##.......",
+		"................",
+		"................",
+	},
+	"wifi2": {
+		"................",
+		"................",
+		"................",
+		"................",
+		"................",
+		".....######.....",
+		"...##......##...",
+		"..#..........#..",
+		"......####......",
+		"....##....##....",
+		"...#........#...",
+		"................",
+		".......##.......",
+		".......##.......",
+		"................",
+		"................",
+	},
+	"wifi3": {
+		"................",
+		"................",
+		"....########....",
+		"..##........##..",
+		".#............#.",
+		".....######.....",
+		"...##......##...",
+		"..#..........#..",
+		"......####......",
+		"....##....##....",
+		"...#........#...",
+		"................",
+		".......##.......",
+		".......##.......",
+		"................",
+		"................",
+	},
+	"ethernet": {
+		"................",
+		"................",
+		"................",
+		".....######.....",
+		".....#....#.....",
+		"..####....####..",
+		"..#..........#..",
+		"..#..........#..",
+		"..#..........#..",
+		"..#.#.#..#.#.#..",
+		"..#.#.#..#.#.#..",
+		"..#.#.#..#.#.#..",
+		"..#..........#..",
+		"..############..",
+		"................",
+		"................",
+	},
+	"warning": {
+		".......##.......",
+		".......##.......",
+		"......#..#......",
+		"......#..#......",
+		".....#....#.....",
+		".....#.##.#.....",
+		"....#..##..#....",
+		"....#..##..#....",
+		"...#...##...#...",
+		"...#...##...#...",
+		"..#....##....#..",
+		"..#..........#..",
+		".#.....##.....#.",
+		".#.....##.....#.",
+		"#..............#",
+		"################",
+	},
+	"fan": {
+		"................",
+		"....####........",
+		"...######.......",
+		"...######...##..",
+		"....#####..####.",
+		"......###..####.",
+		".......##.#####.",
+		"..#############.",
+		".#############..",
+		".#####.##.......",
+		".####..###......",
+		".####..#####....",
+		"..##...######...",
+		".......######...",
+		"........####....",
+		"................",
+	},
+	"clock": {
+		".....######.....",
+		"...##......##...",
+		"..#..........#..",
+		".#.....#......#.",
+		".#.....#......#.",
+		"#......#.......#",
+		"#......#.......#",
+		"#......#.......#",
+		"#......#####...#",
+		"#..............#",
+		"#..............#",
+		".#............#.",
+		".#............#.",
+		"..#..........#..",
+		"...##......##...",
+		".....######.....",
+	},
+	"check": {
+		"................",
+		"................",
+		"................",
+		"................",
+		"..............##",
+		".............##.",
+		"............##..",
+		"...........##...",
+		"..........##....",
+		".##......##.....",
+		"..##....##......",
+		"...##..##.......",
+		"....####........",
+		".....##.........",
+		"................",
+		"................",
+	},
+	"celsius": {
+		"................",
+		"................",
+		".##.....#####...",
+		"#..#...##...##..",
+		"#..#..##.....#..",
+		".##...#.........",
+		"......#.........",
+		"......#.........",
+		"......#.........",
+		"......#.........",
+		"......##.....#..",
+		".......##...##..",
+		"........#####...",
+		"................",
+		"................",
+		"................",
+	},
+}
+
+var (
+	iconMutex sync.Mutex
+	// 已轉換的圖示，以名稱或檔案與大小為鍵值，載入失敗時為 nil
+	iconCache = map[string]*image1bit.VerticalLSB{}
+)
+
+// 依名稱取得 size x size 的圖示，ICON_DIR 中同名的 PNG 優先，找不到時回傳 nil
+func getIcon(name string, size int) *image1bit.VerticalLSB {
+	if dir := iconDir(); dir != "" {
+		path := filepath.Join(dir, name+".png")
+		if _, err := os.Stat(path); err == nil {
+			return loadIconFile(path, size, size)
+		}
+	}
+	return builtinIcon(name, size)
+}
+
+// 內建圖示，size 為 16 以上時使用 16x16 圖示，再依整數倍放大到 size
+func builtinIcon(name string, size int) *image1bit.VerticalLSB {
+	rows, ok := builtinIcons[name]
+	if !ok {
+		return nil
+	}
+	if rows16, ok := builtinIcons16[name]; ok && size >= len(rows16) {
+		rows = rows16
+	}
+	key := fmt.Sprintf("builtin:%s@%d", name, size)
+	iconMutex.Lock()
+	defer iconMutex.Unlock()
+	if icon, ok := iconCache[key]; ok {
+		return icon
+	}
+
+	scale := max(size/len(rows), 1)
+	icon := image1bit.NewVerticalLSB(image.Rect(0, 0, len(rows[0])*scale, len(rows)*scale))
+	c := newCanvas(icon)
+	for y, row := range rows {
+		for x, ch := range row {
+			if ch == '#' {
+				c.fillRect(image.Rect(x*scale, y*scale, (x+1)*scale, (y+1)*scale))
+			}
+		}
+	}
+	iconCache[key] = icon
+	return icon
+}
+
+// 載入 PNG 圖示並縮放至 w x h，轉換結果會被快取
+func loadIconFile(path string, w, h int) *image1bit.VerticalLSB {
+	if path == "" || w <= 0 || h <= 0 {
+		return nil
+	}
+	key := fmt.Sprintf("%s@%dx%d", path, w, h)
+	iconMutex.Lock()
+	defer iconMutex.Unlock()
+	icon, ok := iconCache[key]
+	if !ok {
+		// 載入失敗也記錄下來，避免每次重試
+		frames, _, err := decodeFrames(path)
+		if err != nil {
+			log.Printf("圖示 %s 載入失敗: %v", path, err)
+		} else {
+			icon = convertTo1Bit(frames[0], convertOptions{Width: w, Height: h, Threshold: 128})
+		}
+		iconCache[key] = icon
+	}
+	return icon
+}
+
+// 清除快取，圖示檔案或 ICON_DIR 變更時重新載入
+func clearIconCache() {
+	iconMutex.Lock()
+	clear(iconCache)
+	iconMutex.Unlock()
+}
+
+// 在 (x, y) 繪製圖示，回傳圖示寬度，找不到圖示時不繪製
+func drawIcon(img *image1bit.VerticalLSB, x, y, size int, name string) int {
+	icon := getIcon(name, size)
+	if icon == nil {
+		return 0
+	}
+	newCanvas(img).icon(x, y, icon)
+	return icon.Bounds().Dx()
+}
+
+// 取 .env 檔案中的 ICON_DIR 設定
+func iconDir() string {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return strings.TrimSpace(envConfig["ICON_DIR"])
+}
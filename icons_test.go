@@ -0,0 +1,68 @@
+package main
+
+import (
+	"image"
+	"testing"
+
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+// 每個內建圖示都有 8x8 與 16x16 兩種大小
+func TestBuiltinIconSets(t *testing.T) {
+	check := func(set map[string][]string, size int) {
+		for name, rows := range set {
+			if len(rows) != size {
+				t.Errorf("%s: %d rows, want %d", name, len(rows), size)
+			}
+			for i, row := range rows {
+				if len(row) != size {
+					t.Errorf("%s row %d: width %d, want %d", name, i, len(row), size)
+				}
+			}
+		}
+	}
+	check(builtinIcons, 8)
+	check(builtinIcons16, 16)
+	for name := range builtinIcons {
+		if _, ok := builtinIcons16[name]; !ok {
+			t.Errorf("%s: no 16x16 icon", name)
+		}
+	}
+	for name := range builtinIcons16 {
+		if _, ok := builtinIcons[name]; !ok {
+			t.Errorf("%s: no 8x8 icon", name)
+		}
+	}
+}
+
+func TestBuiltinIconSize(t *testing.T) {
+	t.Cleanup(clearIconCache)
+	tests := []struct {
+		size int
+		rows []string
+		want image.Rectangle
+	}{
+		{8, builtinIcons["check"], image.Rect(0, 0, 8, 8)},
+		{16, builtinIcons16["check"], image.Rect(0, 0, 16, 16)},
+		// 32 由 16x16 放大 2 倍
+		{32, builtinIcons16["check"], image.Rect(0, 0, 32, 32)},
+	}
+	for _, tt := range tests {
+		icon := builtinIcon("check", tt.size)
+		if icon.Bounds() != tt.want {
+			t.Errorf("size %d: bounds %v, want %v", tt.size, icon.Bounds(), tt.want)
+			continue
+		}
+		scale := tt.size / len(tt.rows)
+		for y := range tt.size {
+			for x := range tt.size {
+				if want := image1bit.Bit(tt.rows[y/scale][x/scale] == '#'); icon.BitAt(x, y) != want {
+					t.Errorf("size %d: pixel (%d,%d) = %v, want %v", tt.size, x, y, icon.BitAt(x, y), want)
+				}
+			}
+		}
+	}
+	if builtinIcon("missing", 16) != nil {
+		t.Error("unknown icon should be nil")
+	}
+}
@@ -0,0 +1,426 @@
+// 自訂頁面：從 JSON 版面檔案讀取元件與位置，修改後自動重新載入
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"image"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+// 版面檔案
+type layoutFile struct {
+	Pages []layoutPage `json:"pages"`
+}
+
+// 一個自訂頁面
+type layoutPage struct {
+	Name    string         `json:"name"`
+	Widgets []layoutWidget `json:"widgets"`
+}
+
+// 頁面元件，座標為像素或畫面大小的百分比
+type layoutWidget struct {
+	Type   string      `json:"type"`   // title、underline、value、unit、text、bar、vbar、gauge、ring、sparkline、icon
+	Text   string      `json:"text"`   // title、unit、text 的文字
+	Metric string      `json:"metric"` // value、bar、sparkline 綁定的數值名稱
+	Format string      `json:"format"` // value、text 的格式，例如 %.1f°C
+	X      layoutCoord `json:"x"`
+	Y      layoutCoord `json:"y"`
+	W      layoutCoord `json:"w"`
+	H      layoutCoord `json:"h"`
+	Align  string      `json:"align"` // left、center、right
+	Min    float64     `json:"min"`   // bar、sparkline 的最小值
+	Max    float64     `json:"max"`   // bar、sparkline 的最大值，0 表示自動
+	Icon   string      `json:"icon"`  // icon 的名稱，內建圖示或 ICON_DIR 中的 PNG
+	File   string      `json:"file"`  // icon 的 PNG 檔案，設定時優先於 icon
+}
+
+// 座標或大小：數字為像素，字串 "50%" 為畫面寬度或高度的百分比，
+// 負的 x、y 從右邊或下方算起，例如 -1 為最右邊的像素
+type layoutCoord struct {
+	value float64
+	pct   bool
+}
+
+func (c *layoutCoord) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &c.value); err == nil {
+		c.pct = false
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("coordinate %s: want a number or a percentage", data)
+	}
+	num, ok := strings.CutSuffix(strings.TrimSpace(s), "%")
+	value, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+	if !ok || err != nil {
+		return fmt.Errorf("coordinate %q: want a number or a percentage", s)
+	}
+	c.value, c.pct = value, true
+	return nil
+}
+
+// 換算為像素，size 為畫面的寬度或高度
+func (c layoutCoord) px(size int) int {
+	if c.pct {
+		return int(math.Round(c.value * float64(size) / 100))
+	}
+	return int(math.Round(c.value))
+}
+
+// 換算為位置，負數從右邊或下方算起
+func (c layoutCoord) pos(size int) int {
+	p := c.px(size)
+	if p < 0 {
+		p += size
+	}
+	return p
+}
+
+// 內建頁面數量，自訂頁面從下一頁開始
+const builtinPages = 11
+
+var (
+	layoutMutex sync.RWMutex
+	layoutPages []layoutPage
+
+	// sparkline 使用的歷史數值
+	historyMutex  sync.Mutex
+	metricHistory = map[string][]float64{}
+)
+
+// 歷史數值最多保留的筆數，與畫面寬度相同
+const historySize = 128
+
+// 載入版面檔案，未設定 LAYOUT_FILE 時沒有自訂頁面
+func reloadLayout() {
+	path := layoutPath()
+	var pages []layoutPage
+	if path != "" {
+		var err error
+		pages, err = loadLayout(path)
+		if err != nil {
+			log.Printf("版面檔案 %s 載入失敗: %v", path, err)
+			return
+		}
+		log.Printf("版面檔案 %s 載入 %d 個頁面\n", path, len(pages))
+	}
+
+	layoutMutex.Lock()
+	layoutPages = pages
+	layoutMutex.Unlock()
+	clearIconCache()
+}
+
+// 最後一頁的頁碼，自訂頁面接在內建頁面之後
+func pageCount() int {
+	layoutMutex.RLock()
+	defer layoutMutex.RUnlock()
+	return builtinPages + len(layoutPages)
+}
+
+// 解析版面檔案
+func loadLayout(path string) ([]layoutPage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f layoutFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	for i, page := range f.Pages {
+		for j, w := range page.Widgets {
+			switch w.Type {
+			case "title", "underline", "value", "unit", "text", "bar", "vbar", "gauge", "ring", "sparkline", "icon":
+			default:
+				return nil, fmt.Errorf("page %d widget %d: unknown type %q", i+1, j+1, w.Type)
+			}
+			if w.Metric == "" && (w.Type == "value" || w.Type == "bar" || w.Type == "vbar" ||
+				w.Type == "gauge" || w.Type == "ring" || w.Type == "sparkline") {
+				return nil, fmt.Errorf("page %d widget %d: %s needs a metric", i+1, j+1, w.Type)
+			}
+		}
+	}
+	return f.Pages, nil
+}
+
+// 取得第 n 個自訂頁面 (從 0 開始)
+func getLayoutPage(n int) (layoutPage, bool) {
+	layoutMutex.RLock()
+	defer layoutMutex.RUnlock()
+	if n < 0 || n >= len(layoutPages) {
+		return layoutPage{}, false
+	}
+	return layoutPages[n], true
+}
+
+// 繪製自訂頁面
+func drawLayoutPage(img *image1bit.VerticalLSB, page layoutPage) {
+	c := newCanvas(img)
+	size := img.Bounds().Size()
+	for _, w := range page.Widgets {
+		x, y := w.X.pos(size.X), w.Y.pos(size.Y)
+		width, height := w.W.px(size.X), w.H.px(size.Y)
+		switch w.Type {
+		case "title":
+			drawTitle(img, w.Text)
+
+		case "underline":
+			if width == 0 {
+				width = size.X - x
+			}
+			c.fillRect(image.Rect(x, y, x+width, y+max(height, 1)))
+
+		case "value":
+			value, ok := metricValue(w.Metric)
+			text := "--"
+			if ok {
+				text = formatMetric(w.Format, value)
+			}
+			if height == 0 {
+				height = 24
+			}
+			switch w.Align {
+			case "right":
+				x -= digitsWidth(height, text)
+			case "center":
+				x -= digitsWidth(height, text) / 2
+			}
+			drawDigits(img, x, y, height, text)
+
+		case "unit", "text":
+			text := w.Text
+			if w.Metric != "" {
+				if value, ok := metricValue(w.Metric); ok {
+					text = formatMetric(w.Format, value)
+				} else {
+					text = "--"
+				}
+			}
+			drawWidgetText(img, x, y, width, w.Align, text)
+
+		case "bar":
+			value, _ := metricValue(w.Metric)
+			drawBar(img, scalePct(value, w.Min, w.Max, 100), width, height, x, y)
+
+		case "vbar":
+			value, _ := metricValue(w.Metric)
+			c.vbar(image.Rect(x, y, x+width, y+height), scalePct(value, w.Min, w.Max, 100))
+
+		case "gauge", "ring":
+			// x、y 為圓心，w 為半徑，h 為粗細
+			value, _ := metricValue(w.Metric)
+			thick := max(height, 3)
+			if w.Type == "gauge" {
+				c.gauge(x, y, width, thick, scalePct(value, w.Min, w.Max, 100))
+			} else {
+				c.ring(x, y, width, thick, scalePct(value, w.Min, w.Max, 100))
+			}
+
+		case "sparkline":
+			drawSparkline(c, w, image.Rect(x, y, x+width, y+height))
+
+		case "icon":
+			if w.File != "" {
+				c.icon(x, y, loadIconFile(w.File, width, height))
+			} else {
+				// w 為圖示大小，預設 16
+				c.icon(x, y, getIcon(w.Icon, cmp.Or(width, 16)))
+			}
+		}
+	}
+}
+
+// 依對齊方式繪製文字，width 為對齊的寬度
+func drawWidgetText(img *image1bit.VerticalLSB, x, y, width int, align, text string) {
+	if width == 0 {
+		drawText(img, x, y, text)
+		return
+	}
+	a := alignLeft
+	switch align {
+	case "center":
+		a = alignCenter
+	case "right":
+		a = alignRight
+	}
+	drawTextAligned(img, x, x+width, y, text, a)
+}
+
+// 格式化數值，未指定格式時取一位小數
+func formatMetric(format string, value float64) string {
+	if format == "" {
+		format = "%.1f"
+	}
+	return fmt.Sprintf(format, value)
+}
+
+// 將數值換算為百分比，max 為 0 時使用 defaultMax
+func scalePct(value, minValue, maxValue, defaultMax float64) float64 {
+	if maxValue == 0 {
+		maxValue = defaultMax
+	}
+	if maxValue <= minValue {
+		return 0
+	}
+	return min(max((value-minValue)/(maxValue-minValue)*100, 0), 100)
+}
+
+// 繪製折線圖於 r 範圍內，使用背景收集的歷史數值
+func drawSparkline(c *canvas, w layoutWidget, r image.Rectangle) {
+	historyMutex.Lock()
+	values := metricHistory[w.Metric]
+	historyMutex.Unlock()
+	if len(values) == 0 || r.Dx() <= 0 || r.Dy() <= 0 {
+		return
+	}
+	if len(values) > r.Dx() {
+		values = values[len(values)-r.Dx():]
+	}
+
+	// 未指定最大值時依資料自動縮放
+	minValue, maxValue := w.Min, w.Max
+	if maxValue == 0 {
+		for _, v := range values {
+			maxValue = max(maxValue, v)
+		}
+	}
+	prevY := -1
+	for i, v := range values {
+		x := r.Max.X - len(values) + i
+		y := r.Max.Y - 1 - int(scalePct(v, minValue, maxValue, 1)/100*float64(r.Dy()-1)+0.5)
+		// 與前一點之間補上垂直線，讓折線連續
+		top, bottom := y, y
+		if prevY >= 0 {
+			top, bottom = min(y, prevY), max(y, prevY)
+		}
+		c.vline(x, top, bottom-top+1)
+		prevY = y
+	}
+}
+
+// 定期收集 sparkline 使用的數值
+func monitorLayoutMetrics() {
+	for {
+		layoutMutex.RLock()
+		var names []string
+		for _, page := range layoutPages {
+			for _, w := range page.Widgets {
+				if w.Type == "sparkline" {
+					names = append(names, w.Metric)
+				}
+			}
+		}
+		layoutMutex.RUnlock()
+
+		for _, name := range names {
+			value, ok := metricValue(name)
+			if !ok {
+				continue
+			}
+			historyMutex.Lock()
+			history := append(metricHistory[name], value)
+			if len(history) > historySize {
+				history = history[len(history)-historySize:]
+			}
+			metricHistory[name] = history
+			historyMutex.Unlock()
+		}
+		time.Sleep(layoutSampleInterval())
+	}
+}
+
+// 依名稱取得數值，供自訂頁面使用
+//
+//	cpu.usage、cpu.temp、mem.pct、mem.used、mem.total、swap.used、swap.total
+//	disk.pct、disk.used、disk.total、nvme.temp、nvme.wear、alerts
+//	probe.<名稱>.up、probe.<名稱>.latency
+//
+// 記憶體與磁碟的單位為 GB，latency 的單位為毫秒
+func metricValue(name string) (float64, bool) {
+	const gb = 1024 * 1024 * 1024
+	switch name {
+	case "cpu.usage":
+		return getCPUUsage(), true
+	case "cpu.temp":
+		return getCPUTemperature(), true
+	case "mem.pct":
+		return getMemoryInfo().Pct, true
+	case "mem.used":
+		return getMemoryInfo().Used / gb, true
+	case "mem.total":
+		return getMemoryInfo().Total / gb, true
+	case "swap.used":
+		return getMemoryInfo().SwapUsed / gb, true
+	case "swap.total":
+		return getMemoryInfo().SwapTotal / gb, true
+	case "disk.pct":
+		_, _, _, pct := getDiskSpace()
+		return pct, true
+	case "disk.used":
+		_, _, used, _ := getDiskSpace()
+		return used, true
+	case "disk.total":
+		total, _, _, _ := getDiskSpace()
+		return total, true
+	case "nvme.temp":
+		h := getStorageHealth()
+		return h.NVMeTemp, h.NVMeTemp >= 0
+	case "nvme.wear":
+		h := getStorageHealth()
+		return float64(h.NVMeUsed), h.NVMeUsed >= 0
+	case "alerts":
+		return float64(len(activeAlerts())), true
+	}
+
+	if rest, found := strings.CutPrefix(name, "probe."); found {
+		i := strings.LastIndex(rest, ".")
+		if i < 0 {
+			return 0, false
+		}
+		for _, r := range getProbeResults() {
+			if r.Name != rest[:i] {
+				continue
+			}
+			switch rest[i+1:] {
+			case "up":
+				if r.Up {
+					return 1, true
+				}
+				return 0, true
+			case "latency":
+				return float64(r.Latency.Microseconds()) / 1000, r.Up
+			}
+		}
+	}
+	return 0, false
+}
+
+// 取 .env 檔案中的 LAYOUT_FILE 設定
+func layoutPath() string {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return strings.TrimSpace(envConfig["LAYOUT_FILE"])
+}
+
+// 取 .env 檔案中的 LAYOUT_SAMPLE 設定（秒）
+func layoutSampleInterval() time.Duration {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	interval, err := strconv.Atoi(envConfig["LAYOUT_SAMPLE"])
+	if err != nil || interval <= 0 {
+		return 5 * time.Second // 預設值
+	}
+	return time.Duration(interval) * time.Second
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestLayoutCoord(t *testing.T) {
+	var w layoutWidget
+	err := json.Unmarshal([]byte(`{"x": -1, "y": "50%", "w": " 25 %", "h": 12.4}`), &w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{"x from right", w.X.pos(128), 127},
+		{"x from right portrait", w.X.pos(64), 63},
+		{"y percent", w.Y.pos(64), 32},
+		{"y percent portrait", w.Y.pos(128), 64},
+		{"w percent", w.W.px(128), 32},
+		{"h pixels", w.H.px(64), 12},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %d, want %d", tt.name, tt.got, tt.want)
+		}
+	}
+
+	for _, data := range []string{`{"x": "50"}`, `{"x": "abc%"}`, `{"x": true}`} {
+		if err := json.Unmarshal([]byte(data), &w); err == nil {
+			t.Errorf("%s: error = nil, want invalid coordinate", data)
+		}
+	}
+}
+
+// 靠右下與百分比的元件在橫向與直向畫面都位於相同的相對位置
+func TestDrawLayoutPageAnchored(t *testing.T) {
+	var page layoutPage
+	err := json.Unmarshal([]byte(`{"widgets": [
+		{"type": "underline", "x": -4, "y": -2, "h": 2},
+		{"type": "underline", "x": "25%", "y": "50%", "w": "50%"}
+	]}`), &page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		w, h int
+		want []string
+	}{
+		{16, 8, []string{
+			"................",
+			"................",
+			"................",
+			"................",
+			"....########....",
+			"................",
+			"............####",
+			"............####",
+		}},
+		{8, 16, []string{
+			"........",
+			"........",
+			"........",
+			"........",
+			"........",
+			"........",
+			"........",
+			"........",
+			"..####..",
+			"........",
+			"........",
+			"........",
+			"........",
+			"........",
+			"....####",
+			"....####",
+		}},
+	}
+	for _, tt := range tests {
+		got := renderCanvas(tt.w, tt.h, func(c *canvas) { drawLayoutPage(c.img, page) })
+		checkPixels(t, got, tt.want)
+	}
+}
+
+func TestLoadLayoutExample(t *testing.T) {
+	pages, err := loadLayout("layout.example.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 2 {
+		t.Errorf("got %d pages, want 2", len(pages))
+	}
+}
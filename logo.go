@@ -0,0 +1,208 @@
+// 從 PNG / BMP / GIF 檔案載入 LOGO，轉換為 1 位元 VerticalLSB 格式
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "golang.org/x/image/bmp"
+	xdraw "golang.org/x/image/draw"
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+// 圖片轉換選項
+type convertOptions struct {
+	Width     int    // 目標寬度
+	Height    int    // 目標高度
+	Threshold uint8  // 亮度大於等於此值時點亮
+	Dither    string // threshold 或 floyd
+	Invert    bool   // 反相
+}
+
+// 載入圖片檔案，回傳每一幀的像素資料與延遲時間
+func loadLogo(path string, opts convertOptions) ([][]byte, []time.Duration, error) {
+	images, delays, err := decodeFrames(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	frames := make([][]byte, 0, len(images))
+	for _, src := range images {
+		frames = append(frames, convertTo1Bit(src, opts).Pix)
+	}
+	return frames, delays, nil
+}
+
+// 解碼圖片，GIF 會合成所有幀，其他格式只有一幀
+func decodeFrames(path string) ([]image.Image, []time.Duration, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".gif") {
+		g, err := gif.DecodeAll(f)
+		if err != nil {
+			return nil, nil, err
+		}
+		return composeGIF(g)
+	}
+
+	src, _, err := image.Decode(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	return []image.Image{src}, []time.Duration{0}, nil
+}
+
+// 依照 GIF 的處置方式合成每一幀完整畫面
+func composeGIF(g *gif.GIF) ([]image.Image, []time.Duration, error) {
+	if len(g.Image) == 0 {
+		return nil, nil, fmt.Errorf("gif has no frames")
+	}
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	if bounds.Empty() {
+		bounds = g.Image[0].Bounds()
+	}
+	canvas := image.NewRGBA(bounds)
+
+	frames := make([]image.Image, 0, len(g.Image))
+	delays := make([]time.Duration, 0, len(g.Image))
+	for i, frame := range g.Image {
+		var previous *image.RGBA
+		disposal := byte(0)
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+		if disposal == gif.DisposalPrevious {
+			previous = image.NewRGBA(bounds)
+			xdraw.Copy(previous, image.Point{}, canvas, bounds, xdraw.Src, nil)
+		}
+
+		xdraw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, xdraw.Over)
+		snapshot := image.NewRGBA(bounds)
+		xdraw.Copy(snapshot, image.Point{}, canvas, bounds, xdraw.Src, nil)
+		frames = append(frames, snapshot)
+
+		// GIF 延遲單位為 1/100 秒
+		delay := 0
+		if i < len(g.Delay) {
+			delay = g.Delay[i]
+		}
+		delays = append(delays, time.Duration(delay)*10*time.Millisecond)
+
+		switch disposal {
+		case gif.DisposalBackground:
+			xdraw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, xdraw.Src)
+		case gif.DisposalPrevious:
+			canvas = previous
+		}
+	}
+	return frames, delays, nil
+}
+
+// 內建 LOGO 為 128x64，其他尺寸或旋轉後的畫面縮放後使用
+func builtinLogo(bounds image.Rectangle) [][]byte {
+	logoRect := image.Rect(0, 0, 128, 64)
+	if bounds == logoRect {
+		return logoImage
+	}
+	opts := logoConvertOptions(bounds)
+	// 內建 LOGO 已經是 1 位元資料，不需要抖動或反相
+	opts.Dither, opts.Invert = "", false
+	frames := make([][]byte, len(logoImage))
+	for i, data := range logoImage {
+		src := image1bit.NewVerticalLSB(logoRect)
+		copy(src.Pix, data)
+		frames[i] = convertTo1Bit(src, opts).Pix
+	}
+	return frames
+}
+
+// 等比例縮放置中，轉換為 1 位元圖片
+func convertTo1Bit(src image.Image, opts convertOptions) *image1bit.VerticalLSB {
+	sb := src.Bounds()
+	scale := min(float64(opts.Width)/float64(sb.Dx()), float64(opts.Height)/float64(sb.Dy()))
+	w := max(int(float64(sb.Dx())*scale+0.5), 1)
+	h := max(int(float64(sb.Dy())*scale+0.5), 1)
+	offset := image.Pt((opts.Width-w)/2, (opts.Height-h)/2)
+
+	// 透明區域視為黑色背景
+	gray := image.NewGray(image.Rect(0, 0, opts.Width, opts.Height))
+	xdraw.Draw(gray, gray.Bounds(), image.Black, image.Point{}, xdraw.Src)
+	dst := image.Rectangle{Min: offset, Max: offset.Add(image.Pt(w, h))}
+	xdraw.CatmullRom.Scale(gray, dst, src, sb, xdraw.Over, nil)
+
+	if opts.Invert {
+		for i, v := range gray.Pix {
+			gray.Pix[i] = 255 - v
+		}
+	}
+
+	out := image1bit.NewVerticalLSB(gray.Bounds())
+	if opts.Dither == "floyd" {
+		floydSteinberg(gray, out, opts.Threshold)
+	} else {
+		for y := range gray.Bounds().Dy() {
+			for x := range gray.Bounds().Dx() {
+				out.SetBit(x, y, image1bit.Bit(gray.GrayAt(x, y).Y >= opts.Threshold))
+			}
+		}
+	}
+	return out
+}
+
+// Floyd–Steinberg 誤差擴散抖動
+func floydSteinberg(gray *image.Gray, out *image1bit.VerticalLSB, threshold uint8) {
+	w, h := gray.Bounds().Dx(), gray.Bounds().Dy()
+	errs := make([]float64, w*h)
+	for i, v := range gray.Pix[:w*h] {
+		errs[i] = float64(v)
+	}
+	spread := func(x, y int, e float64) {
+		if x >= 0 && x < w && y < h {
+			errs[y*w+x] += e
+		}
+	}
+	for y := range h {
+		for x := range w {
+			old := errs[y*w+x]
+			on := old >= float64(threshold)
+			out.SetBit(x, y, image1bit.Bit(on))
+			value := 0.0
+			if on {
+				value = 255
+			}
+			e := old - value
+			spread(x+1, y, e*7/16)
+			spread(x-1, y+1, e*3/16)
+			spread(x, y+1, e*5/16)
+			spread(x+1, y+1, e*1/16)
+		}
+	}
+}
+
+// 取 .env 檔案中的 LOGO_DITHER、LOGO_THRESHOLD、LOGO_INVERT 設定
+func logoConvertOptions(bounds image.Rectangle) convertOptions {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	opts := convertOptions{
+		Width:     bounds.Dx(),
+		Height:    bounds.Dy(),
+		Threshold: 128, // 預設值
+		Dither:    strings.ToLower(envConfig["LOGO_DITHER"]),
+		Invert:    strings.ToLower(envConfig["LOGO_INVERT"]) == "true",
+	}
+	if v, err := strconv.Atoi(envConfig["LOGO_THRESHOLD"]); err == nil && v >= 0 && v <= 255 {
+		opts.Threshold = uint8(v)
+	}
+	return opts
+}
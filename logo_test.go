@@ -0,0 +1,149 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"strings"
+	"testing"
+	"time"
+
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+// 由每列的灰階值建立圖片
+func grayImage(rows ...[]uint8) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, len(rows[0]), len(rows)))
+	for y, row := range rows {
+		copy(img.Pix[y*img.Stride:], row)
+	}
+	return img
+}
+
+func TestConvertTo1Bit(t *testing.T) {
+	src := grayImage([]uint8{0, 127, 128, 255})
+	tests := []struct {
+		name string
+		src  image.Image
+		opts convertOptions
+		want []string
+	}{
+		{"threshold", src, convertOptions{Width: 4, Height: 1, Threshold: 128}, []string{"..##"}},
+		{"threshold 0", src, convertOptions{Width: 4, Height: 1, Threshold: 0}, []string{"####"}},
+		{"invert", src, convertOptions{Width: 4, Height: 1, Threshold: 128, Invert: true}, []string{"##.."}},
+		// 等比例縮放後上下置中，其餘為黑色背景
+		{"letterbox", grayImage([]uint8{255, 255}), convertOptions{Width: 4, Height: 4, Threshold: 128}, []string{
+			"....",
+			"####",
+			"####",
+			"....",
+		}},
+	}
+	for _, tt := range tests {
+		got := pixelRows(convertTo1Bit(tt.src, tt.opts))
+		if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFloydSteinberg(t *testing.T) {
+	tests := []struct {
+		name string
+		gray *image.Gray
+		want []string
+	}{
+		// 第一點 100 未點亮，誤差的 7/16 使第二點達到 143.75
+		{"error to the right", grayImage([]uint8{100, 100}), []string{".#"}},
+		{"black", grayImage([]uint8{0, 0}, []uint8{0, 0}), []string{"..", ".."}},
+		{"white", grayImage([]uint8{255, 255}, []uint8{255, 255}), []string{"##", "##"}},
+		// 誤差往下一列擴散
+		{"error below", grayImage([]uint8{100}, []uint8{100}), []string{".", "#"}},
+	}
+	for _, tt := range tests {
+		out := image1bit.NewVerticalLSB(tt.gray.Bounds())
+		floydSteinberg(tt.gray, out, 128)
+		if got := pixelRows(out); strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// 50% 灰階約有一半的點點亮
+	const n = 16
+	rows := make([][]uint8, n)
+	for y := range rows {
+		rows[y] = make([]uint8, n)
+		for x := range rows[y] {
+			rows[y][x] = 128
+		}
+	}
+	gray := grayImage(rows...)
+	out := image1bit.NewVerticalLSB(gray.Bounds())
+	floydSteinberg(gray, out, 128)
+	on := strings.Count(strings.Join(pixelRows(out), ""), "#")
+	if on < n*n*2/5 || on > n*n*3/5 {
+		t.Errorf("50%% gray: %d of %d pixels on, want about half", on, n*n)
+	}
+}
+
+// 依序為：完整白色、右下黑點後清除為背景、左上黑點後還原、左下黑點
+func TestComposeGIF(t *testing.T) {
+	palette := color.Palette{color.Black, color.White, color.Transparent}
+	frame := func(r image.Rectangle, index uint8) *image.Paletted {
+		img := image.NewPaletted(r, palette)
+		for i := range img.Pix {
+			img.Pix[i] = index
+		}
+		return img
+	}
+	g := &gif.GIF{
+		Image: []*image.Paletted{
+			frame(image.Rect(0, 0, 2, 2), 1),
+			frame(image.Rect(1, 1, 2, 2), 0),
+			frame(image.Rect(0, 0, 1, 1), 0),
+			frame(image.Rect(0, 1, 1, 2), 0),
+		},
+		Delay:    []int{10, 5, 5, 20},
+		Disposal: []byte{gif.DisposalNone, gif.DisposalBackground, gif.DisposalPrevious, gif.DisposalNone},
+		Config:   image.Config{Width: 2, Height: 2},
+	}
+
+	frames, delays, err := composeGIF(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// W 白色、B 黑色、T 透明
+	want := []string{"WW/WW", "WW/WB", "BW/WT", "WW/BT"}
+	if len(frames) != len(want) {
+		t.Fatalf("got %d frames, want %d", len(frames), len(want))
+	}
+	for i, img := range frames {
+		var b strings.Builder
+		for y := range 2 {
+			if y > 0 {
+				b.WriteByte('/')
+			}
+			for x := range 2 {
+				r, _, _, a := img.At(x, y).RGBA()
+				switch {
+				case a == 0:
+					b.WriteByte('T')
+				case r > 0x8000:
+					b.WriteByte('W')
+				default:
+					b.WriteByte('B')
+				}
+			}
+		}
+		if b.String() != want[i] {
+			t.Errorf("frame %d = %s, want %s", i, b.String(), want[i])
+		}
+	}
+	if delays[0] != 100*time.Millisecond || delays[3] != 200*time.Millisecond {
+		t.Errorf("delays = %v, want 100ms ... 200ms", delays)
+	}
+
+	if _, _, err := composeGIF(&gif.GIF{}); err == nil {
+		t.Error("empty gif: error = nil")
+	}
+}
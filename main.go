@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -14,9 +15,6 @@ import (
 
 	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/gpio/gpioreg"
-	"periph.io/x/conn/v3/i2c/i2creg"
-	"periph.io/x/devices/v3/ssd1306"
-	"periph.io/x/devices/v3/ssd1306/image1bit"
 	"periph.io/x/host/v3"
 )
 
@@ -26,97 +24,130 @@ var (
 	configMutex sync.RWMutex
 	envConfig   map[string]string
 
-	showLOGO      bool
-	showDHT       bool
-	DHTType       string
-	DHTPin        string
-	onLoop        bool
-	sleepTime     time.Duration
-	originalSleep time.Duration
-	firstRun      bool
-	stepBy        int
-	stepEnd       int
-
-	button1Pin    gpio.PinIO
-	button2Pin    gpio.PinIO
-	button3Pin    gpio.PinIO
-	button4Pin    gpio.PinIO
-	buttonPage    int
+	showLOGO bool
+	logoPath string
+	showDHT  bool
+	DHTType  string
+	DHTPin   string
+
+	// 顯示器，第一個為主要顯示器
+	screens []*screen
+
+	// 多個顯示器同時顯示溫/濕度時，避免同時讀取感應器
+	dhtMutex sync.Mutex
+
 	led1Pin       gpio.PinIO
 	ledStateMutex sync.Mutex
+
+	// NVMe / SD 卡 健康狀態
+	showStorage      bool
+	nvmeDevice       string
+	smartctlCmd      string
+	sdDevice         string
+	storageTempAlert float64
+	storageWearAlert int
+
+	// systemd 服務狀態
+	showSystemd  bool
+	systemdUnits []string
+
+	// Docker 容器狀態
+	showDocker   bool
+	dockerSocket string
+
+	// 連線檢查
+	showProbes bool
+
+	// 時鐘
+	showClock bool
+	clockLoc  = time.Local
 )
 
 func main() {
+	// 子命令
+	if len(os.Args) > 1 && os.Args[1] == "convert" {
+		if err := runConvert(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "screenshot" {
+		if err := runScreenshot(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// 首次載入配置
 	envConfig = loadEnv()
 
-	showLOGO = shouldShowLOGO()
+	showLOGO, logoPath = shouldShowLOGO()
+	reloadTextFace()
 	showDHT, DHTType, DHTPin = shouldShowDHT()
-	onLoop = shouldOnLoop()
-	// 每次循環延遲時間
-	sleepTime = showSleepTime()
-	originalSleep = sleepTime
+	showStorage, nvmeDevice, smartctlCmd, sdDevice = shouldShowStorage()
+	storageTempAlert, storageWearAlert = storageAlertLimits()
+	showSystemd, systemdUnits = shouldShowSystemd()
+	showDocker, dockerSocket = shouldShowDocker()
+	showProbes = shouldShowProbes()
+	showClock = shouldShowClock()
+	clockLoc = clockLocation()
 
-	stepBy = defaultPage()
-	// 最後一個顯示的步驟
-	stepEnd = 6
+	// 自訂頁面接在內建頁面之後
+	reloadLayout()
 
 	// 初始化 Periph.io 硬體層
 	if _, err := host.Init(); err != nil {
 		log.Fatal(err)
 	}
 
-	// 按鈕
-	button1Pin = gpioreg.ByName(button1PinName())
-	button2Pin = gpioreg.ByName(button2PinName())
-	button3Pin = gpioreg.ByName(button3PinName())
-	button4Pin = gpioreg.ByName(button4PinName())
-	buttonPage = setButtonPage()
+	// 初始化顯示器，DISPLAYS 設定多個顯示器時各自有頁面、切換時間與按鈕
+	var err error
+	screens, err = openScreens()
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, s := range screens {
+		defer s.closeBus()
+		defer s.dev.Halt()
+	}
+
 	led1Pin = gpioreg.ByName(led1PinName())
 
 	// 初始化 GPIO 按鈕和 LED
 	initGPIO()
 
-	// 程式開始第一次執行
-	firstRun = true
-
 	// 啟動檔案監控 Goroutine
 	go monitorEnvFile()
 
-	// 為每個按鈕啟動一個 goroutine 來監聽按下事件
-	go waitForButtonPress(button1Pin, "Button 1")
-	go waitForButtonPress(button2Pin, "Button 2")
-	go waitForButtonPress(button3Pin, "Button 3")
-	go waitForButtonPress(button4Pin, "Button 4")
+	// 啟動警示檢查與 LED 警示燈號
+	alertChecks = append(alertChecks, checkStorageAlerts, checkSystemdAlerts)
+	go monitorAlerts()
+	go alertLED()
 
-	// 初始化 I2C 匯流排
-	bus, err := i2creg.Open("")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer bus.Close()
+	// 啟動連線檢查與 HTTP 端點
+	go monitorProbes()
+	startHTTPServer()
 
-	// 初始化 SSD1306 顯示器 (地址 0x3C)
-	opts := ssd1306.DefaultOpts
-	opts.W = 128
-	opts.H = 64
-	dev, err := ssd1306.NewI2C(bus, &opts)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer dev.Halt()
+	// 收集自訂頁面折線圖的數值
+	go monitorLayoutMetrics()
 
-	// 創建 image1bit.Image
-	// bounds := dev.Bounds()
-	// img := image1bit.NewVerticalLSB(bounds)
-	img := image1bit.NewVerticalLSB(dev.Bounds())
+	// 為每個按鈕啟動一個 goroutine 來監聽按下事件
+	for _, s := range screens {
+		for i, pin := range s.buttonPins() {
+			if pin != nil {
+				go waitForButtonPress(s, i+1)
+			}
+		}
+	}
 
 	// 設置中斷訊號處理
 	sigChan := make(chan os.Signal, 1)
 	// signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	signal.Notify(sigChan, syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP)
 
-	quitChan := make(chan bool)
+	// 關閉 quitChan 通知所有顯示器結束
+	quitChan := make(chan struct{})
+	quit := sync.OnceFunc(func() { close(quitChan) })
 
 	go func() {
 		s := <-sigChan
@@ -131,10 +162,27 @@ func main() {
 			log.Fatalf("Failed to set LED pin %s as output: %v", led1Pin, err)
 		}
 
-		quitChan <- true
+		quit()
 	}()
 
-	// 主循環：讀取資訊並顯示
+	// 每個顯示器由各自的 goroutine 繪製，任一個結束時結束程式
+	done := make(chan struct{}, len(screens))
+	for _, s := range screens {
+		go func() {
+			s.run(quitChan)
+			done <- struct{}{}
+		}()
+	}
+	<-done
+	quit()
+	for range len(screens) - 1 {
+		<-done
+	}
+}
+
+// 主循環：讀取資訊並顯示，quitChan 關閉時顯示結束畫面後返回
+func (s *screen) run(quitChan <-chan struct{}) {
+	dev, img := s.dev, s.img
 	for {
 		select {
 		// 優雅關閉程式
@@ -142,8 +190,7 @@ func main() {
 			fmt.Println("\n接收到中斷訊號，程式即將結束...")
 
 			clearImage(img)
-			drawText(img, 2, 0, testCenter("STOP", 18))
-			drawText(img, 0, 3, "___________________")
+			drawHeader(img, "STOP")
 			drawLargeText(img, 0, 7, "Bye", 3) // 縮放 3 倍
 			// 更新顯示
 			if err := dev.Draw(dev.Bounds(), img, image.Point{}); err != nil {
@@ -166,22 +213,63 @@ func main() {
 			dev.Draw(dev.Bounds(), img, image.Point{}) // 清空螢幕
 			return
 		default:
+			// 夜間時段降低對比、只顯示時鐘或關閉顯示器
+			night := s.nightMode(clockNow())
+			s.applyNightContrast(night)
+			if night == "clock" || night == "off" {
+				s.runNight(night, quitChan)
+				continue
+			}
+
+			// 閒置時關閉顯示器
+			if s.autoOffDue() {
+				s.runAutoOff(quitChan)
+				continue
+			}
+
+			// 閒置時顯示螢幕保護程式，否則依時間位移、反白畫面
+			burnIn := loadBurnInConfig(s.prefix)
+			if s.screensaverDue(burnIn) {
+				s.runScreensaver(burnIn, quitChan)
+				continue
+			}
+			s.applyBurnIn(burnIn, time.Now())
+
 			clearImage(img)
+			s.resetMarquees()
+			s.live = nil
+			// 128x32 等矮的畫面使用精簡版面
+			compact := compactLayout(img)
+			right := img.Bounds().Dx() - 1
+
+			// 不在 PAGES 設定中的頁面直接跳過
+			page, end := s.currentPage(), pageCount()
+			if !s.firstRun && page > 0 && page <= end && !s.showsPage(page) {
+				s.skipPage(page)
+				continue
+			}
 
 			switch {
-			case stepBy == 0 || firstRun:
-				if showLOGO && firstRun {
+			case page == 0 || s.firstRun:
+				if showLOGO && s.firstRun {
+					frames, delays := builtinLogo(dev.Bounds()), []time.Duration(nil)
+					if logoPath != "" {
+						var err error
+						frames, delays, err = loadLogo(logoPath, logoConvertOptions(dev.Bounds()))
+						if err != nil {
+							log.Printf("LOGO 載入失敗，使用內建 LOGO: %v", err)
+							frames, delays = builtinLogo(dev.Bounds()), nil
+						}
+					}
 					// 連續顯示所有幀
-					showBMP(logoImage, dev, img, dev.Bounds(), 0)
+					showBMP(frames, delays, dev, img, dev.Bounds())
 					time.Sleep(time.Second * 2)
 				}
-				if stepBy == 0 {
-					stepBy++
-				}
-				firstRun = false
+				s.skipPage(0)
+				s.firstRun = false
 				continue
 
-			case stepBy == 1:
+			case page == 1:
 				if showDHT {
 					err := dht.HostInit()
 					if err != nil {
@@ -196,108 +284,293 @@ func main() {
 						return
 					}
 
+					dhtMutex.Lock()
 					hum, temp, err := dht.ReadRetry(11)
+					dhtMutex.Unlock()
 
 					if err != nil {
 						log.Printf("DHT22 讀取失敗: %v", err)
-						lines := splitByN(err.Error(), 18)
-
-						displayPagedError(dev, img, lines)
+						s.displayError(err.Error())
 					} else {
 						// 顯示 攝氏 溫度
 						temp = (temp - 32) * 5.0 / 9.0
 
-						drawText(img, 2, 0, testCenter("Temp / Hum", 18))
-						drawText(img, 0, 3, "___________________")
-						drawLargeText(img, 0, 4, fmt.Sprintf("%.0f", temp), 3)
-						drawLargeText(img, 42, 16, "o", 1)
-						drawLargeText(img, 25, 9, "C", 2)
-						drawLargeText(img, 24, 6, fmt.Sprintf("%.0f", hum), 3)
-						drawLargeText(img, 58, 14, "%", 2)
-						drawText(img, 0, 50, "___________________")
+						drawHeader(img, "Temp / Hum")
+						size, top := 28, 24
+						if compact {
+							size, top = 14, 17
+						}
+						tempStr := fmt.Sprintf("%.0f°C", temp)
+						humStr := fmt.Sprintf("%.0f%%", hum)
+						humTop := top
+						// 直向畫面溫度與濕度上下排列
+						if portraitLayout(img) {
+							size = min(fitDigits(size, img.Bounds().Dx(), tempStr), fitDigits(size, img.Bounds().Dx(), humStr))
+							humTop = top + size + 12
+						}
+						drawDigits(img, 0, top, size, tempStr)
+						drawDigits(img, right-digitsWidth(size, humStr), humTop, size, humStr)
+						drawFooter(img)
 					}
 				} else {
-					stepBy++
+					s.skipPage(page)
 					continue
 				}
-			case stepBy == 2:
+			case page == 2:
 				// 顯示 HOSTNAME IP
 				ipAddress, hostname := getIPAddress()
 
-				drawText(img, 2, 0, testCenter(hostname, 18))
+				s.drawMarquee(0, img.Bounds().Dx(), 0, hostname, alignCenter)
+				if compact {
+					drawTextAligned(img, 0, img.Bounds().Dx(), 16, ipAddress, alignCenter)
+					break
+				}
 				drawText(img, 0, 3, "___________________")
-				drawLargeText(img, 0, 6, ipAddress[:8], 2)
-				drawLargeText(img, 15, 17, fmt.Sprintf("%7s", ipAddress[8:]), 2)
-				drawText(img, 0, 50, "___________________")
+				// IP 分成兩行，前兩段靠左，後兩段靠右
+				if octets := strings.Split(ipAddress, "."); len(octets) == 4 {
+					line1 := octets[0] + "." + octets[1] + "."
+					line2 := octets[2] + "." + octets[3]
+					size := min(fitDigits(18, img.Bounds().Dx(), line1), fitDigits(18, img.Bounds().Dx(), line2))
+					drawDigits(img, 0, 20, size, line1)
+					drawDigits(img, right-digitsWidth(size, line2), 42, size, line2)
+				} else {
+					drawTextAligned(img, 0, img.Bounds().Dx(), 30, ipAddress, alignCenter)
+				}
+				drawFooter(img)
 
-			case stepBy == 3:
+			case page == 3:
 				// 顯示 CPU 使用率
 				cpuUsage := getCPUUsage()
 
-				drawText(img, 2, 0, testCenter("CPU Usage", 18))
-				drawText(img, 0, 3, "___________________")
-				drawLargeText(img, 2, 5, fmt.Sprintf("%5.1f", cpuUsage), 3)
-				drawLargeText(img, 58, 14, "%", 2)
-				drawText(img, 0, 50, "___________________")
+				drawHeader(img, "CPU Usage")
+				cpuStr := fmt.Sprintf("%.1f%%", cpuUsage)
+				size, top := 32, 24
+				if compact {
+					size, top = 14, 17
+				}
+				size = fitDigits(size, img.Bounds().Dx(), cpuStr)
+				drawDigits(img, right-digitsWidth(size, cpuStr), top, size, cpuStr)
+				drawFooter(img)
 
-			case stepBy == 4:
+			case page == 4:
 				// 顯示 CPU 溫度
 				temperature := getCPUTemperature()
 
-				drawText(img, 2, 0, testCenter("CPU Temperature", 18))
-				drawText(img, 0, 3, "___________________")
-				drawLargeText(img, 0, 5, fmt.Sprintf("%.2f", temperature), 3)
-				drawText(img, 106, 18, "o")
-				drawLargeText(img, 58, 10, "C", 2)
-				drawText(img, 0, 50, "___________________")
+				drawHeader(img, "CPU Temperature")
+				tempStr := fmt.Sprintf("%.1f°C", temperature)
+				size, top := 32, 24
+				if compact {
+					size, top = 14, 17
+				}
+				size = fitDigits(size, img.Bounds().Dx(), tempStr)
+				drawDigits(img, right-digitsWidth(size, tempStr), top, size, tempStr)
+				drawFooter(img)
 
-			case stepBy == 5:
+			case page == 5:
 				// 顯示 RAM
-				totalRAM, usedRAM, ramPct := getRAMUsage()
+				mem := getMemoryInfo()
 
-				drawText(img, 2, 0, testCenter("RAM Usage", 18))
-				drawText(img, 0, 3, "___________________")
+				// toggle 時每次進入此頁切換 長條圖 / 明細，停留在此頁時不切換
+				view := memoryView()
+				if view == "toggle" {
+					if s.lastPage != 5 {
+						s.memDetailView = !s.memDetailView
+					}
+				} else {
+					s.memDetailView = view == "detail"
+				}
+
+				if s.memDetailView {
+					lines := []string{fmt.Sprintf("Buf %-5s Cac %s", formatBytesShort(mem.Buffers), formatBytesShort(mem.Cached))}
+					if mem.SwapTotal > 0 {
+						lines = append(lines, fmt.Sprintf("Swp %s / %s", formatBytesShort(mem.SwapUsed), formatBytesShort(mem.SwapTotal)))
+					} else {
+						lines = append(lines, "Swp  N/A")
+					}
+					if mem.ZramCompr > 0 {
+						lines = append(lines, fmt.Sprintf("zRam %.1fx %s", mem.zramRatio(), formatBytesShort(mem.ZramUsed)))
+					} else {
+						lines = append(lines, "zRam N/A")
+					}
+					drawHeader(img, "Memory Detail")
+					drawLines(img, lines)
+					drawFooter(img)
+					break
+				}
+
+				drawHeader(img, "RAM Usage")
 
 				// 使用文字顯示
-				// drawLargeText(img, 6, 6, fmt.Sprintf("%6.2f", ramPct), 2)
+				// drawLargeText(img, 6, 6, fmt.Sprintf("%6.2f", mem.Pct), 2)
 				// drawText(img, 98, 25, "%")
 
 				// 使用長條圖顯示
-				drawBar(img, ramPct, 128, 14, 0, 22)
-
-				drawLargeText(img, 0, 17, fmt.Sprintf("%5.2f", usedRAM), 2)
-				drawText(img, 74, 44, "/")
-				drawLargeText(img, 42, 17, fmt.Sprintf("%2.0f", totalRAM), 2)
-				drawText(img, 114, 48, "GB")
-				drawText(img, 0, 50, "___________________")
+				if compact {
+					drawBar(img, mem.Pct, img.Bounds().Dx(), 14, 0, 17)
+					break
+				}
+				drawBar(img, mem.Pct, img.Bounds().Dx(), 14, 0, 22)
+
+				usedRAM, usedUnit := formatBytes(mem.Used)
+				totalRAM, totalUnit := formatBytes(mem.Total)
+				usedWidth := drawDigits(img, 0, 40, 18, usedRAM)
+				drawText(img, usedWidth+3, 45, usedUnit)
+				// 直向畫面總量放在下一行
+				if portraitLayout(img) {
+					drawTextAligned(img, 0, img.Bounds().Dx(), 61, "/ "+totalRAM+totalUnit, alignRight)
+				} else {
+					drawTextAligned(img, 64, img.Bounds().Dx(), 45, "/ "+totalRAM+totalUnit, alignRight)
+				}
+				drawFooter(img)
 
-			case stepBy == 6:
+			case page == 6:
 				// 顯示 儲存 容量
 				diskTotal, diskFree, diskUsed, diskPct := getDiskSpace()
 				_, _, _, _ = diskTotal, diskFree, diskUsed, diskPct
 
-				drawText(img, 2, 0, testCenter("Disk Used / Total", 18))
-				drawText(img, 0, 3, "___________________")
-				drawLargeText(img, 6, 6, fmt.Sprintf("%7.2f", diskUsed), 2)
-				drawText(img, 112, 25, "GB")
-				drawLargeText(img, 6, 17, fmt.Sprintf("%7.2f", diskTotal), 2)
-				drawText(img, 112, 48, "GB")
-				drawText(img, 0, 50, "___________________")
+				drawHeader(img, "Disk Used / Total")
+				if compact {
+					drawTextAligned(img, 0, img.Bounds().Dx(), 16, fmt.Sprintf("%.1f / %.1f GB", diskUsed, diskTotal), alignCenter)
+					break
+				}
+				usedStr := fmt.Sprintf("%.2f", diskUsed)
+				totalStr := fmt.Sprintf("%.2f", diskTotal)
+				// 數字靠右對齊單位，單位與文字底部對齊
+				unitX := img.Bounds().Dx() - 16
+				size := min(fitDigits(18, unitX-4, usedStr), fitDigits(18, unitX-4, totalStr))
+				drawDigits(img, unitX-4-digitsWidth(size, usedStr), 19, size, usedStr)
+				drawText(img, unitX, 19+size-13, "GB")
+				drawDigits(img, unitX-4-digitsWidth(size, totalStr), 41, size, totalStr)
+				drawText(img, unitX, 41+size-13, "GB")
+				drawFooter(img)
+
+			case page == 7:
+				// 顯示 NVMe / SD 卡 健康狀態
+				if !showStorage {
+					s.skipPage(page)
+					continue
+				}
+				h := getStorageHealth()
+				portrait := portraitLayout(img)
+
+				drawHeader(img, "Storage Health")
+				if h.NVMeTemp >= 0 {
+					nvmeTemp := fmt.Sprintf("NVMe %5.1f", h.NVMeTemp)
+					if portrait {
+						nvmeTemp = fmt.Sprintf("NVMe %.0f", h.NVMeTemp)
+					}
+					drawText(img, 0, 16, nvmeTemp)
+					drawIcon(img, textWidth(nvmeTemp)+1, 21, 8, "celsius")
+				} else {
+					drawText(img, 0, 16, "NVMe   N/A")
+				}
+				if compact {
+					break
+				}
+				if h.NVMeUsed >= 0 {
+					drawText(img, 0, 27, fmt.Sprintf("Wear %3d%%", h.NVMeUsed))
+				}
+				// 直向畫面備用區放在下一行，SD 卡往下移
+				sdY := 38
+				if h.NVMeSpare >= 0 {
+					if portrait {
+						drawText(img, 0, 38, fmt.Sprintf("Spr%3d%%", h.NVMeSpare))
+						sdY = 49
+					} else {
+						drawText(img, 70, 27, fmt.Sprintf("Spr%3d%%", h.NVMeSpare))
+					}
+				}
+				sd := "SD     N/A"
+				switch {
+				case h.SDLife >= 0:
+					sd = fmt.Sprintf("SD  <=%3d%% %s", h.SDLife, h.SDPreEOL)
+				case h.SDPreEOL != "":
+					sd = "SD  " + h.SDPreEOL
+				}
+				for i, line := range wrapText(sd, img.Bounds().Dx()) {
+					drawText(img, 0, sdY+11*i, line)
+				}
+				drawFooter(img)
+
+			case page == 8:
+				// 顯示 systemd 服務狀態
+				if !showSystemd || len(systemdUnits) == 0 {
+					s.skipPage(page)
+					continue
+				}
+				if !s.displayUnitPages(page, getUnitStates(systemdUnits)) {
+					continue
+				}
+
+			case page == 9:
+				// 顯示 Docker 容器狀態
+				if !showDocker {
+					s.skipPage(page)
+					continue
+				}
+				summary, err := getDockerClient(dockerSocket).summary()
+				if err != nil {
+					log.Printf("Docker 讀取失敗: %v", err)
+					s.displayError(err.Error())
+				} else if !s.displayDockerPages(page, summary) {
+					continue
+				}
+
+			case page == 10:
+				// 顯示 連線檢查
+				results := getProbeResults()
+				if !showProbes || len(results) == 0 {
+					s.skipPage(page)
+					continue
+				}
+				if !s.displayProbePages(page, results) {
+					continue
+				}
+
+			case page == 11:
+				// 顯示 時鐘，頁面停留期間每幀重新繪製
+				if !showClock {
+					s.skipPage(page)
+					continue
+				}
+				h24, synced := clock24h(), clockSynced()
+				s.live = func() {
+					clearImage(img)
+					drawClockPage(img, clockNow(), h24, synced)
+				}
+				s.live()
+
+			case page > builtinPages && page <= end:
+				// 顯示 版面檔案中的自訂頁面
+				layout, ok := getLayoutPage(page - builtinPages - 1)
+				if !ok {
+					s.skipPage(page)
+					continue
+				}
+				drawLayoutPage(img, layout)
+
+			default:
+				// 超出最後一頁時回到第一頁，PAGES 中的頁面都沒有顯示時等待後再試
+				if s.wrapped {
+					time.Sleep(s.pageTime())
+				}
+				s.wrapped = true
+				s.setPage(1)
+				continue
 			}
 
 			// 更新顯示
-			if err := dev.Draw(dev.Bounds(), img, image.Point{}); err != nil {
-				log.Fatal(err)
-			}
-			time.Sleep(sleepTime)
+			s.updateDisplay()
+			s.waitPage(s.pageTime())
+			s.wrapped = false
 
-			// 切換顯示狀態頁面，onLoop 為 true 時，則循環顯示
+			// 切換顯示狀態頁面，s.onLoop 為 true 時，則循環顯示
 			// 否則，顯示單頁面
-			if onLoop {
-				stepBy++
+			if s.looping() {
+				s.step(1)
 			}
-			if stepBy > stepEnd {
-				stepBy = 1
+			if s.currentPage() > pageCount() {
+				s.setPage(1)
 			}
 
 		}
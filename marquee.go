@@ -0,0 +1,128 @@
+// 跑馬燈：超出區域寬度的文字水平捲動，頁面顯示期間持續更新
+package main
+
+import (
+	"image"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+// 開始捲動前停留的時間
+const marqueeHold = time.Second
+
+// 文字結尾與下一輪開頭之間的間距
+const marqueeGap = 24
+
+// 捲動中的文字
+type marquee struct {
+	rect  image.Rectangle
+	strip *image1bit.VerticalLSB // 文字加上間距，循環顯示
+	start time.Time
+}
+
+// 開始繪製新的畫面，清除上一個畫面的跑馬燈
+// 各跑馬燈開始的時間保留一個畫面，同一頁重新繪製時接續捲動
+func (s *screen) resetMarquees() {
+	s.marquees = nil
+	s.prevMarqueeStart, s.marqueeStart = s.marqueeStart, s.prevMarqueeStart
+	clear(s.marqueeStart)
+}
+
+// 在 x0 ~ x1 之間繪製文字，寬度足夠時依對齊方式繪製，超出時改為跑馬燈
+func (s *screen) drawMarquee(x0, x1, y int, text string, align textAlign) {
+	img := s.img
+	text = strings.Join(strings.Fields(text), " ")
+	width := textWidth(text)
+	if width <= x1-x0 {
+		drawTextAligned(img, x0, x1, y, text, align)
+		return
+	}
+
+	height := textBaseline() + textDescent()
+	m := &marquee{
+		rect:  image.Rect(x0, y, x1, y+height).Intersect(img.Bounds()),
+		strip: image1bit.NewVerticalLSB(image.Rect(0, 0, width+marqueeGap, height)),
+	}
+	newCanvas(m.strip).text(0, 0, text)
+
+	key := m.rect.String() + text
+	start, ok := s.prevMarqueeStart[key]
+	if !ok {
+		start = time.Now()
+	}
+	m.start = start
+	s.marqueeStart[key] = start
+
+	s.marquees = append(s.marquees, m)
+	m.render(img, time.Now())
+}
+
+// 依經過的時間繪製目前的捲動位置
+func (m *marquee) render(img *image1bit.VerticalLSB, now time.Time) {
+	elapsed := now.Sub(m.start) - marqueeHold
+	cycle := m.strip.Bounds().Dx()
+	offset := 0
+	if elapsed > 0 {
+		offset = int(elapsed.Seconds()*float64(marqueeSpeed())) % cycle
+	}
+	for y := m.rect.Min.Y; y < m.rect.Max.Y; y++ {
+		for x := m.rect.Min.X; x < m.rect.Max.X; x++ {
+			sx := (x - m.rect.Min.X + offset) % cycle
+			img.SetBit(x, y, m.strip.BitAt(sx, y-m.rect.Min.Y))
+		}
+	}
+}
+
+// 頁面停留 d 的時間，有跑馬燈或需要持續更新的頁面時持續更新畫面
+// Draw 只會傳送有變化的區域，不會重送整個畫面
+func (s *screen) waitPage(d time.Duration) {
+	dev, img := s.dev, s.img
+	if len(s.marquees) == 0 && s.live == nil {
+		time.Sleep(d)
+		return
+	}
+	deadline := time.Now().Add(d)
+	frameTime := time.Second / time.Duration(marqueeFPS())
+	for {
+		now := time.Now()
+		if !now.Before(deadline) {
+			return
+		}
+		if s.live != nil {
+			s.live()
+		}
+		for _, m := range s.marquees {
+			m.render(img, now)
+		}
+		if err := dev.Draw(dev.Bounds(), img, image.Point{}); err != nil {
+			log.Fatal(err)
+		}
+		time.Sleep(min(frameTime-time.Since(now), time.Until(deadline)))
+	}
+}
+
+// 取 .env 檔案中的 MARQUEE_SPEED 設定（像素 / 秒）
+func marqueeSpeed() int {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	speed, err := strconv.Atoi(envConfig["MARQUEE_SPEED"])
+	if err != nil || speed <= 0 {
+		return 30 // 預設值
+	}
+	return speed
+}
+
+// 取 .env 檔案中的 MARQUEE_FPS 設定
+func marqueeFPS() int {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	fps, err := strconv.Atoi(envConfig["MARQUEE_FPS"])
+	if err != nil || fps <= 0 {
+		return 15 // 預設值
+	}
+	return fps
+}
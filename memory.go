@@ -0,0 +1,111 @@
+// RAM / Swap / zram 記憶體明細
+package main
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// 記憶體資訊，單位皆為 bytes
+type memoryInfo struct {
+	Total     float64
+	Available float64
+	Used      float64
+	Buffers   float64
+	Cached    float64
+	SwapTotal float64
+	SwapUsed  float64
+	Pct       float64 // RAM 使用率
+
+	ZramOrig  float64 // 壓縮前資料大小
+	ZramCompr float64 // 壓縮後資料大小
+	ZramUsed  float64 // zram 實際佔用記憶體
+}
+
+// 獲取 記憶體 明細
+func getMemoryInfo() memoryInfo {
+	var m memoryInfo
+	memInfo, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return m
+	}
+	defer memInfo.Close()
+
+	var swapFree float64
+	scanner := bufio.NewScanner(memInfo)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), ":")
+		if len(fields) != 2 {
+			continue
+		}
+		key := strings.TrimSpace(fields[0])
+		valueStr := strings.TrimSpace(strings.ReplaceAll(fields[1], " kB", ""))
+		value, err := strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			continue
+		}
+		value *= 1024 // kB 轉 bytes
+		switch key {
+		case "MemTotal":
+			m.Total = value
+		case "MemAvailable":
+			m.Available = value
+		case "Buffers":
+			m.Buffers = value
+		case "Cached":
+			m.Cached = value
+		case "SwapTotal":
+			m.SwapTotal = value
+		case "SwapFree":
+			swapFree = value
+		}
+	}
+	m.Used = m.Total - m.Available
+	m.SwapUsed = m.SwapTotal - swapFree
+	if m.Total > 0 {
+		m.Pct = m.Used / m.Total * 100
+	}
+
+	// zram 統計，mm_stat 前三欄為 壓縮前、壓縮後、實際佔用
+	stats, _ := filepath.Glob("/sys/block/zram*/mm_stat")
+	for _, stat := range stats {
+		content, err := os.ReadFile(stat)
+		if err != nil {
+			continue
+		}
+		fields := strings.Fields(string(content))
+		if len(fields) < 3 {
+			continue
+		}
+		orig, _ := strconv.ParseFloat(fields[0], 64)
+		compr, _ := strconv.ParseFloat(fields[1], 64)
+		used, _ := strconv.ParseFloat(fields[2], 64)
+		m.ZramOrig += orig
+		m.ZramCompr += compr
+		m.ZramUsed += used
+	}
+	return m
+}
+
+// zram 壓縮比，沒有資料時回傳 0
+func (m memoryInfo) zramRatio() float64 {
+	if m.ZramCompr == 0 {
+		return 0
+	}
+	return m.ZramOrig / m.ZramCompr
+}
+
+// 取 .env 檔案中的 MEM_VIEW 設定：bar、detail 或 toggle
+func memoryView() string {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	view := strings.ToLower(envConfig["MEM_VIEW"])
+	switch view {
+	case "detail", "toggle":
+		return view
+	}
+	return "bar" // 預設值
+}
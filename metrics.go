@@ -0,0 +1,93 @@
+// HTTP 端點：Prometheus /metrics 與 JSON /metrics.json，POST /wake 喚醒顯示器，/screenshot.png 截圖
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// 啟動 HTTP 伺服器，HTTP_LISTEN 未設定時不啟動
+func startHTTPServer() {
+	listen := httpListen()
+	if listen == "" {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", handleMetrics)
+	mux.HandleFunc("/metrics.json", handleMetricsJSON)
+	mux.HandleFunc("POST /wake", handleWake)
+	mux.HandleFunc("GET /screenshot.png", handleScreenshot)
+
+	go func() {
+		log.Printf("HTTP 伺服器啟動於 %s\n", listen)
+		if err := http.ListenAndServe(listen, mux); err != nil {
+			log.Println("HTTP 伺服器錯誤:", err)
+		}
+	}()
+}
+
+// Prometheus 文字格式
+func handleMetrics(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	results := getProbeResults()
+
+	fmt.Fprintln(w, "# HELP oled_probe_up Whether the reachability check succeeded.")
+	fmt.Fprintln(w, "# TYPE oled_probe_up gauge")
+	for _, r := range results {
+		up := 0
+		if r.Up {
+			up = 1
+		}
+		fmt.Fprintf(w, "oled_probe_up{%s} %d\n", probeLabels(r.probe), up)
+	}
+	fmt.Fprintln(w, "# HELP oled_probe_latency_seconds Duration of the last reachability check.")
+	fmt.Fprintln(w, "# TYPE oled_probe_latency_seconds gauge")
+	for _, r := range results {
+		fmt.Fprintf(w, "oled_probe_latency_seconds{%s} %g\n", probeLabels(r.probe), r.Latency.Seconds())
+	}
+}
+
+// Prometheus 標籤，需跳脫反斜線、引號與換行
+func probeLabels(p probe) string {
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return fmt.Sprintf(`name="%s",kind="%s",target="%s"`,
+		escape.Replace(p.Name), escape.Replace(p.Kind), escape.Replace(p.Target))
+}
+
+// JSON 格式
+func handleMetricsJSON(w http.ResponseWriter, _ *http.Request) {
+	type probeJSON struct {
+		Name      string  `json:"name"`
+		Kind      string  `json:"kind"`
+		Target    string  `json:"target"`
+		Up        bool    `json:"up"`
+		LatencyMS float64 `json:"latency_ms"`
+		Error     string  `json:"error,omitempty"`
+		Checked   string  `json:"checked"`
+	}
+	var probes []probeJSON
+	for _, r := range getProbeResults() {
+		probes = append(probes, probeJSON{
+			Name:      r.Name,
+			Kind:      r.Kind,
+			Target:    r.Target,
+			Up:        r.Up,
+			LatencyMS: float64(r.Latency.Microseconds()) / 1000,
+			Error:     r.Err,
+			Checked:   r.Checked.Format(time.RFC3339),
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"probes": probes})
+}
+
+// 取 .env 檔案中的 HTTP_LISTEN 設定，例如 :9101
+func httpListen() string {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return envConfig["HTTP_LISTEN"]
+}
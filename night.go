@@ -0,0 +1,172 @@
+// 夜間模式：依時段降低對比、只顯示時鐘或關閉顯示器，按下按鈕暫時喚醒
+package main
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+// 夜間模式的時段，start、end 為一天中的分鐘數，end 小於 start 時跨過午夜
+type nightRule struct {
+	start, end int
+	mode       string // dim、clock、off
+}
+
+// 目前時間適用的夜間模式，按下按鈕喚醒期間不適用，沒有時回傳空白
+func (s *screen) nightMode(now time.Time) string {
+	s.mu.Lock()
+	awake := now.Before(s.wakeUntil)
+	s.mu.Unlock()
+	if awake {
+		return ""
+	}
+	minute := now.Hour()*60 + now.Minute()
+	for _, r := range nightSchedule(s.prefix) {
+		if r.start <= r.end && minute >= r.start && minute < r.end {
+			return r.mode
+		}
+		if r.start > r.end && (minute >= r.start || minute < r.end) {
+			return r.mode
+		}
+	}
+	return ""
+}
+
+// 依夜間模式調整對比，dim 時降低，其他時候恢復最大值
+func (s *screen) applyNightContrast(mode string) {
+	level := byte(0xFF)
+	if mode == "dim" {
+		level = nightContrast(s.prefix)
+	}
+	if level == s.contrast {
+		return
+	}
+	if err := s.dev.SetContrast(level); err != nil {
+		log.Printf("設定對比失敗: %v", err)
+		return
+	}
+	s.contrast = level
+}
+
+// 顯示時鐘或關閉顯示器，直到時段結束、按下按鈕或程式結束
+func (s *screen) runNight(mode string, quitChan <-chan struct{}) {
+	log.Printf("%s 進入夜間模式 %s", s, mode)
+	s.setAsleep(true)
+	defer s.setAsleep(false)
+
+	dev, img := s.dev, s.img
+	if mode == "off" {
+		s.displayOff()
+	}
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		if mode == "clock" {
+			clearImage(img)
+			drawNightClock(img, clockNow())
+			if err := dev.Draw(dev.Bounds(), img, image.Point{}); err != nil {
+				log.Fatal(err)
+			}
+		}
+		select {
+		case <-quitChan:
+			return
+		case <-ticker.C:
+		}
+		if s.nightMode(clockNow()) != mode {
+			break
+		}
+	}
+	if mode == "off" {
+		s.displayOn()
+	}
+	// 回到頁面時不播放切換效果
+	s.lastFrame = nil
+	log.Printf("%s 結束夜間模式", s)
+}
+
+// 夜間時鐘：只有置中的大時間
+func drawNightClock(img *image1bit.VerticalLSB, now time.Time) {
+	text := now.Format("15:04")
+	height := fitDigits(min(32, img.Bounds().Dy()-4), img.Bounds().Dx(), text)
+	x := (img.Bounds().Dx() - digitsWidth(height, text)) / 2
+	drawDigits(img, x, (img.Bounds().Dy()-height)/2, height, text)
+}
+
+// 取 .env 檔案中的 NIGHT_SCHEDULE 設定
+// 以逗號分隔的時段，例如 22:00-07:00 off,07:00-08:00 dim
+func nightSchedule(prefix string) []nightRule {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	var rules []nightRule
+	for _, field := range strings.Split(screenEnv(prefix, "NIGHT_SCHEDULE"), ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		r, err := parseNightRule(field)
+		if err != nil {
+			log.Printf("NIGHT_SCHEDULE 設定錯誤 %q: %v", field, err)
+			continue
+		}
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+// 解析 HH:MM-HH:MM mode
+func parseNightRule(field string) (nightRule, error) {
+	span, mode, _ := strings.Cut(field, " ")
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	switch mode {
+	case "dim", "clock", "off":
+	default:
+		return nightRule{}, fmt.Errorf("模式只支援 dim、clock、off: %q", mode)
+	}
+	from, to, ok := strings.Cut(span, "-")
+	if !ok {
+		return nightRule{}, errors.New("時段格式為 HH:MM-HH:MM")
+	}
+	start, err := time.Parse("15:04", strings.TrimSpace(from))
+	if err != nil {
+		return nightRule{}, err
+	}
+	end, err := time.Parse("15:04", strings.TrimSpace(to))
+	if err != nil {
+		return nightRule{}, err
+	}
+	return nightRule{
+		start: start.Hour()*60 + start.Minute(),
+		end:   end.Hour()*60 + end.Minute(),
+		mode:  mode,
+	}, nil
+}
+
+// 取 .env 檔案中的 NIGHT_CONTRAST 設定，dim 時的對比 0 ~ 255
+func nightContrast(prefix string) byte {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	level, err := strconv.Atoi(screenEnv(prefix, "NIGHT_CONTRAST"))
+	if err != nil || level < 0 || level > 255 {
+		return 16 // 預設值
+	}
+	return byte(level)
+}
+
+// 取 .env 檔案中的 NIGHT_WAKE 設定，按下按鈕後喚醒的秒數
+func nightWake(prefix string) time.Duration {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	seconds, err := strconv.Atoi(screenEnv(prefix, "NIGHT_WAKE"))
+	if err != nil || seconds <= 0 {
+		return 30 * time.Second // 預設值
+	}
+	return time.Duration(seconds) * time.Second
+}
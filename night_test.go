@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNightMode(t *testing.T) {
+	defer func(cfg map[string]string) { envConfig = cfg }(envConfig)
+	envConfig = map[string]string{"NIGHT_SCHEDULE": "22:00-07:00 off, 07:00-08:00 dim, bad"}
+
+	s := &screen{}
+	taipei := time.FixedZone("UTC+8", 8*3600)
+	tests := []struct {
+		now  time.Time
+		want string
+	}{
+		{time.Date(2026, 1, 1, 23, 30, 0, 0, taipei), "off"},
+		{time.Date(2026, 1, 1, 6, 59, 0, 0, taipei), "off"},
+		{time.Date(2026, 1, 1, 7, 0, 0, 0, taipei), "dim"},
+		{time.Date(2026, 1, 1, 12, 0, 0, 0, taipei), ""},
+		// 同一時間在 UTC 為 15:30，時段依傳入時間的時區判斷
+		{time.Date(2026, 1, 1, 23, 30, 0, 0, taipei).UTC(), ""},
+	}
+	for _, tt := range tests {
+		if got := s.nightMode(tt.now); got != tt.want {
+			t.Errorf("nightMode(%s) = %q, want %q", tt.now.Format("15:04 MST"), got, tt.want)
+		}
+	}
+}
+
+// 夜間模式與時鐘頁面同樣依 CLOCK_TZ 時區
+func TestNightModeClockTZ(t *testing.T) {
+	defer func(cfg map[string]string, loc *time.Location) { envConfig, clockLoc = cfg, loc }(envConfig, clockLoc)
+	clockLoc = time.FixedZone("UTC+8", 8*3600)
+	now := clockNow()
+	start := now.Add(-time.Hour).Format("15:04")
+	end := now.Add(time.Hour).Format("15:04")
+	envConfig = map[string]string{"NIGHT_SCHEDULE": start + "-" + end + " clock"}
+
+	if got := (&screen{}).nightMode(clockNow()); got != "clock" {
+		t.Errorf("nightMode(clockNow()) = %q, want clock", got)
+	}
+}
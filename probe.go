@@ -0,0 +1,232 @@
+// 連線檢查：TCP、HTTP、DNS
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 檢查項目，例如 nas=tcp://192.168.1.10:445
+type probe struct {
+	Name   string
+	Kind   string // tcp、http、https、dns
+	Target string // tcp 為 host:port，http 為完整網址，dns 為主機名稱
+}
+
+// 檢查結果
+type probeResult struct {
+	probe
+	Up      bool
+	Latency time.Duration
+	Err     string
+	Checked time.Time
+}
+
+var (
+	probeMutex   sync.RWMutex
+	probeResults []probeResult
+
+	// dns 檢查使用的解析器
+	probeResolver = net.DefaultResolver
+)
+
+// 解析 PROBES 設定，格式為 name=kind://target，以逗號分隔
+func parseProbes(s string) []probe {
+	var probes []probe
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, target, found := strings.Cut(item, "=")
+		if !found {
+			name, target = item, item
+		}
+		u, err := url.Parse(strings.TrimSpace(target))
+		if err != nil || u.Scheme == "" {
+			log.Printf("PROBES 設定錯誤: %s", item)
+			continue
+		}
+		p := probe{Name: strings.TrimSpace(name), Kind: strings.ToLower(u.Scheme)}
+		switch p.Kind {
+		case "tcp":
+			p.Target = u.Host
+		case "http", "https":
+			p.Target = u.String()
+		case "dns":
+			p.Target = u.Host
+		default:
+			log.Printf("PROBES 不支援的類型: %s", item)
+			continue
+		}
+		probes = append(probes, p)
+	}
+	return probes
+}
+
+// 執行單一檢查
+func runProbe(p probe, timeout time.Duration) probeResult {
+	r := probeResult{probe: p, Checked: time.Now()}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	var err error
+	switch p.Kind {
+	case "tcp":
+		var conn net.Conn
+		var d net.Dialer
+		conn, err = d.DialContext(ctx, "tcp", p.Target)
+		if err == nil {
+			conn.Close()
+		}
+	case "http", "https":
+		var req *http.Request
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, p.Target, nil)
+		if err == nil {
+			var resp *http.Response
+			resp, err = http.DefaultClient.Do(req)
+			if err == nil {
+				resp.Body.Close()
+				if resp.StatusCode >= 400 {
+					err = fmt.Errorf("HTTP %d", resp.StatusCode)
+				}
+			}
+		}
+	case "dns":
+		var addrs []string
+		addrs, err = probeResolver.LookupHost(ctx, p.Target)
+		if err == nil && len(addrs) == 0 {
+			err = fmt.Errorf("no address for %s", p.Target)
+		}
+	}
+	r.Latency = time.Since(start)
+	r.Up = err == nil
+	if err != nil {
+		r.Err = err.Error()
+	}
+	return r
+}
+
+// 定期執行所有檢查
+func monitorProbes() {
+	for {
+		time.Sleep(checkProbes())
+	}
+}
+
+// 執行一次所有檢查並更新警示，回傳下次檢查前的間隔
+// SHOW_PROBES 為 false 時不檢查，清除結果與警示
+func checkProbes() time.Duration {
+	probes, interval, timeout := probeConfig()
+	if !showProbes {
+		probes = nil
+	}
+	results := make([]probeResult, len(probes))
+	var wg sync.WaitGroup
+	for i, p := range probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runProbe(p, timeout)
+		}()
+	}
+	wg.Wait()
+
+	var down []string
+	for _, r := range results {
+		if !r.Up {
+			down = append(down, r.Name)
+		}
+	}
+	setAlert("probe", len(down) > 0, "unreachable: "+strings.Join(down, ", "))
+
+	probeMutex.Lock()
+	probeResults = results
+	probeMutex.Unlock()
+	return interval
+}
+
+// 最近一次檢查結果
+func getProbeResults() []probeResult {
+	probeMutex.RLock()
+	defer probeMutex.RUnlock()
+	return append([]probeResult(nil), probeResults...)
+}
+
+// 分頁顯示檢查清單，每頁 3 項（精簡版面 1 項），最後一頁由主循環更新顯示
+// 按鈕切換到其他頁面時回傳 false
+func (s *screen) displayProbePages(page int, results []probeResult) bool {
+	const lineHeight = 11
+	img := s.img
+	rows := itemLines(img)
+	linesPerPage := max(contentLines(img)/rows, 1)
+
+	pages := (len(results) + linesPerPage - 1) / linesPerPage
+	for i := 0; i < len(results); i += linesPerPage {
+		clearImage(img)
+		end := min(i+linesPerPage, len(results))
+
+		title := "Reachability"
+		if pages > 1 {
+			title = fmt.Sprintf("Reach %d/%d", i/linesPerPage+1, pages)
+		}
+		drawHeader(img, title)
+
+		for j, r := range results[i:end] {
+			icon, status := "warning", "DOWN"
+			if r.Up {
+				icon, status = "check", fmt.Sprintf("%dms", r.Latency.Milliseconds())
+			}
+			y := 16 + lineHeight*rows*j
+			// 狀態在名稱右邊，直向畫面在下一行
+			split, statusY := img.Bounds().Dx()-textWidth(status)-4, y
+			if rows > 1 {
+				split, statusY = img.Bounds().Dx(), y+lineHeight
+			}
+			drawIcon(img, 0, y+5, 8, icon)
+			drawTextAligned(img, 12, split, y, r.Name, alignLeft)
+			drawTextAligned(img, 0, img.Bounds().Dx(), statusY, status, alignRight)
+		}
+		drawFooter(img)
+
+		if end < len(results) {
+			// 更新顯示
+			s.updateDisplay()
+			if !s.holdPage(page, s.pageTime()) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// 取 .env 檔案中的 連線檢查 設定
+func shouldShowProbes() bool {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return strings.ToLower(envConfig["SHOW_PROBES"]) == "true"
+}
+
+// 取 .env 檔案中的 PROBES、PROBE_INTERVAL、PROBE_TIMEOUT 設定
+func probeConfig() ([]probe, time.Duration, time.Duration) {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	interval, err := strconv.Atoi(envConfig["PROBE_INTERVAL"])
+	if err != nil || interval <= 0 {
+		interval = 30 // 預設值
+	}
+	timeout, err := strconv.Atoi(envConfig["PROBE_TIMEOUT"])
+	if err != nil || timeout <= 0 {
+		timeout = 3 // 預設值
+	}
+	return parseProbes(envConfig["PROBES"]), time.Duration(interval) * time.Second, time.Duration(timeout) * time.Second
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseProbes(t *testing.T) {
+	got := parseProbes("gw=tcp://192.168.1.1:53, web=https://example.com/health,dns=dns://example.com,bad,ftp=ftp://x")
+	want := []probe{
+		{Name: "gw", Kind: "tcp", Target: "192.168.1.1:53"},
+		{Name: "web", Kind: "https", Target: "https://example.com/health"},
+		{Name: "dns", Kind: "dns", Target: "example.com"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseProbes() = %+v, want %+v", got, want)
+	}
+}
+
+func TestProbeTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	if r := runProbe(probe{Kind: "tcp", Target: ln.Addr().String()}, time.Second); !r.Up {
+		t.Errorf("open port: Up = false, Err = %s", r.Err)
+	}
+
+	// 關閉後的埠號沒有在監聽
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := closed.Addr().String()
+	closed.Close()
+	if r := runProbe(probe{Kind: "tcp", Target: addr}, time.Second); r.Up || r.Err == "" {
+		t.Errorf("closed port: Up = %v, Err = %q", r.Up, r.Err)
+	}
+}
+
+func TestProbeHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	if r := runProbe(probe{Kind: "http", Target: srv.URL + "/ok"}, time.Second); !r.Up {
+		t.Errorf("200: Up = false, Err = %s", r.Err)
+	}
+	r := runProbe(probe{Kind: "http", Target: srv.URL + "/broken"}, time.Second)
+	if r.Up || r.Err != "HTTP 500" {
+		t.Errorf("500: Up = %v, Err = %q, want HTTP 500", r.Up, r.Err)
+	}
+}
+
+func TestProbeHTTPTimeout(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		<-block
+	}))
+	defer srv.Close()
+	defer close(block)
+
+	r := runProbe(probe{Kind: "http", Target: srv.URL}, 50*time.Millisecond)
+	if r.Up || !strings.Contains(r.Err, "deadline") {
+		t.Errorf("Up = %v, Err = %q, want deadline exceeded", r.Up, r.Err)
+	}
+}
+
+func TestProbeDNS(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+	go serveFakeDNS(pc, "ok.test.")
+
+	// 所有查詢都送到本機假的 DNS 伺服器
+	old := probeResolver
+	probeResolver = &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "udp", pc.LocalAddr().String())
+		},
+	}
+	defer func() { probeResolver = old }()
+
+	if r := runProbe(probe{Kind: "dns", Target: "ok.test"}, time.Second); !r.Up {
+		t.Errorf("ok.test: Up = false, Err = %s", r.Err)
+	}
+	if r := runProbe(probe{Kind: "dns", Target: "missing.test"}, time.Second); r.Up {
+		t.Error("missing.test: Up = true, want NXDOMAIN")
+	}
+}
+
+// 最簡單的 DNS 伺服器：name 的 A 查詢回答 127.0.0.1，AAAA 沒有資料，其他名稱回答 NXDOMAIN
+func serveFakeDNS(pc net.PacketConn, name string) {
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := pc.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		if n < 12 {
+			continue
+		}
+		// 問題區段：標籤序列、類型、類別
+		end := 12
+		var labels []string
+		for end < n && buf[end] != 0 {
+			l := int(buf[end])
+			labels = append(labels, string(buf[end+1:end+1+l]))
+			end += 1 + l
+		}
+		end += 5
+		if end > n {
+			continue
+		}
+		qtype := binary.BigEndian.Uint16(buf[end-4:])
+		qname := strings.ToLower(strings.Join(labels, ".") + ".")
+
+		resp := append([]byte(nil), buf[:end]...)
+		resp[2] = 0x84 | buf[2]&0x01 // QR、AA，保留 RD
+		resp[3] = 0x80               // RA
+		binary.BigEndian.PutUint16(resp[6:], 0)
+		binary.BigEndian.PutUint16(resp[8:], 0)
+		binary.BigEndian.PutUint16(resp[10:], 0)
+		switch {
+		case qname != name:
+			resp[3] |= 3 // NXDOMAIN
+		case qtype == 1:
+			binary.BigEndian.PutUint16(resp[6:], 1)
+			resp = append(resp, 0xC0, 12, 0, 1, 0, 1, 0, 0, 0, 60, 0, 4, 127, 0, 0, 1)
+		}
+		pc.WriteTo(resp, addr)
+	}
+}
+
+func TestCheckProbesDisabled(t *testing.T) {
+	defer func(show bool, cfg map[string]string) { showProbes, envConfig = show, cfg }(showProbes, envConfig)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+	envConfig = map[string]string{"PROBES": "x=tcp://" + addr, "PROBE_TIMEOUT": "1"}
+
+	// 啟用時連不上會發出警示
+	showProbes = true
+	checkProbes()
+	if msg := alertFor("probe"); msg != "unreachable: x" {
+		t.Errorf("enabled: alert = %q, want unreachable: x", msg)
+	}
+	if len(getProbeResults()) != 1 {
+		t.Errorf("enabled: %d results, want 1", len(getProbeResults()))
+	}
+
+	// 停用後不檢查，清除結果與警示
+	showProbes = false
+	checkProbes()
+	if msg := alertFor("probe"); msg != "" {
+		t.Errorf("disabled: alert = %q, want none", msg)
+	}
+	if len(getProbeResults()) != 0 {
+		t.Errorf("disabled: %d results, want 0", len(getProbeResults()))
+	}
+}
+
+func alertFor(key string) string {
+	alertMutex.Lock()
+	defer alertMutex.Unlock()
+	return alerts[key]
+}
@@ -0,0 +1,153 @@
+// 差異更新：與上一個畫面比較，只傳送有變化的 page / column 區塊
+// 同時處理畫面旋轉、鏡像與防烙印位移，頁面以旋轉後的寬高繪製
+package main
+
+import (
+	"image"
+	"sync"
+
+	"golang.org/x/image/draw"
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+// 同一個 page 中相距小於此欄數的變化合併傳送，減少設定位址的指令
+const mergeGap = 4
+
+// 差異更新的顯示器，其餘方法直接使用驅動程式
+type diffDisplay struct {
+	oledDriver
+	rotate int         // 順時針旋轉角度：0、90、180、270
+	flipH  bool        // 左右鏡像
+	flipV  bool        // 上下鏡像
+	offset image.Point // 防烙印的整體位移，超出畫面的部分不顯示
+
+	mu      sync.Mutex             // 保護 logical，截圖時由其他 goroutine 讀取
+	logical *image1bit.VerticalLSB // 旋轉前的畫面，非整頁繪製時合成用
+	shown   *image1bit.VerticalLSB // 顯示器上目前的畫面
+	next    *image1bit.VerticalLSB // 旋轉後準備傳送的畫面
+}
+
+func newDiffDisplay(dev oledDriver, rotate int, flipH, flipV bool) *diffDisplay {
+	return &diffDisplay{oledDriver: dev, rotate: rotate, flipH: flipH, flipV: flipV}
+}
+
+// 旋轉後的畫面範圍，90 與 270 度時寬高互換
+func (d *diffDisplay) Bounds() image.Rectangle {
+	b := d.oledDriver.Bounds()
+	if d.rotate == 90 || d.rotate == 270 {
+		return image.Rect(0, 0, b.Dy(), b.Dx())
+	}
+	return b
+}
+
+// 是否需要轉換畫面
+func (d *diffDisplay) transformed() bool {
+	return d.rotate != 0 || d.flipH || d.flipV || d.offset != (image.Point{})
+}
+
+// 繪製畫面，每個有變化的區塊分別傳送
+// 驅動程式本身只會傳送包含所有變化的最小矩形，兩個角落同時變化時幾乎等於整個畫面
+func (d *diffDisplay) Draw(r image.Rectangle, src image.Image, sp image.Point) error {
+	bounds := d.Bounds()
+	physical := d.oledDriver.Bounds()
+	first := d.shown == nil
+	d.mu.Lock()
+	if first {
+		d.logical = image1bit.NewVerticalLSB(bounds)
+		d.shown = image1bit.NewVerticalLSB(physical)
+		d.next = image1bit.NewVerticalLSB(physical)
+	}
+
+	frame, ok := src.(*image1bit.VerticalLSB)
+	if !ok || r != bounds || frame.Rect != bounds || sp != (image.Point{}) {
+		// 非整頁繪製，先合成到暫存畫面
+		draw.Src.Draw(d.logical, r, src, sp)
+		frame = d.logical
+	} else {
+		// 保留目前的畫面，之後非整頁繪製時合成用
+		copy(d.logical.Pix, frame.Pix)
+	}
+	d.mu.Unlock()
+
+	next := frame
+	if d.transformed() {
+		d.orient(d.next, frame)
+		next = d.next
+	}
+
+	if first {
+		// 第一次繪製，整個畫面傳送
+		copy(d.shown.Pix, next.Pix)
+		_, err := d.oledDriver.Write(d.shown.Pix)
+		return err
+	}
+
+	width := physical.Dx()
+	for page := range physical.Dy() / 8 {
+		row := page * width
+		for col := 0; col < width; {
+			if d.shown.Pix[row+col] == next.Pix[row+col] {
+				col++
+				continue
+			}
+			// 找出這個區塊的結尾，相距很近的變化一起傳送
+			end, last := col+1, col
+			for ; end < width && end-last <= mergeGap; end++ {
+				if d.shown.Pix[row+end] != next.Pix[row+end] {
+					last = end
+				}
+			}
+			copy(d.shown.Pix[row+col:row+last+1], next.Pix[row+col:row+last+1])
+			// 驅動程式與目前的畫面比較後只會傳送這個區塊
+			if _, err := d.oledDriver.Write(d.shown.Pix); err != nil {
+				return err
+			}
+			col = last + 1
+		}
+	}
+	return nil
+}
+
+// 目前顯示的畫面（旋轉前）的複本，尚未繪製時回傳 nil
+func (d *diffDisplay) snapshot() *image1bit.VerticalLSB {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.logical == nil {
+		return nil
+	}
+	img := image1bit.NewVerticalLSB(d.logical.Rect)
+	copy(img.Pix, d.logical.Pix)
+	return img
+}
+
+// 將旋轉前的畫面 src 依設定位移、鏡像、旋轉後寫入 dst
+func (d *diffDisplay) orient(dst, src *image1bit.VerticalLSB) {
+	clear(dst.Pix)
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	for y := range h {
+		for x := range w {
+			sx, sy := x+d.offset.X, y+d.offset.Y
+			if sx < 0 || sx >= w || sy < 0 || sy >= h {
+				continue
+			}
+			if d.flipH {
+				sx = w - 1 - sx
+			}
+			if d.flipV {
+				sy = h - 1 - sy
+			}
+			var px, py int
+			switch d.rotate {
+			case 90:
+				px, py = h-1-sy, sx
+			case 180:
+				px, py = w-1-sx, h-1-sy
+			case 270:
+				px, py = sy, w-1-sx
+			default:
+				px, py = sx, sy
+			}
+			dst.SetBit(px, py, src.BitAt(x, y))
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"image"
+	"math/bits"
+	"slices"
+	"testing"
+
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+// 記錄每次 Write 與上一次內容不同的位元組，與實際驅動程式相同只傳送變化
+type recordingDriver struct {
+	bounds image.Rectangle
+	pix    []byte
+	sent   [][]int // 每次 Write 變化的位元組位置
+}
+
+func (d *recordingDriver) Bounds() image.Rectangle { return d.bounds }
+
+func (d *recordingDriver) Draw(image.Rectangle, image.Image, image.Point) error { return nil }
+
+func (d *recordingDriver) Write(pixels []byte) (int, error) {
+	var changed []int
+	for i, b := range pixels {
+		if d.pix == nil || d.pix[i] != b {
+			changed = append(changed, i)
+		}
+	}
+	d.pix = slices.Clone(pixels)
+	d.sent = append(d.sent, changed)
+	return len(pixels), nil
+}
+
+func (d *recordingDriver) Halt() error            { return nil }
+func (d *recordingDriver) SetContrast(byte) error { return nil }
+func (d *recordingDriver) Invert(bool) error      { return nil }
+
+func TestDiffDisplayDraw(t *testing.T) {
+	drv := &recordingDriver{bounds: image.Rect(0, 0, 128, 64)}
+	d := newDiffDisplay(drv, 0, false, false)
+	img := image1bit.NewVerticalLSB(d.Bounds())
+	draw := func() [][]int {
+		t.Helper()
+		drv.sent = nil
+		if err := d.Draw(d.Bounds(), img, image.Point{}); err != nil {
+			t.Fatal(err)
+		}
+		return drv.sent
+	}
+
+	// 第一次繪製傳送整個畫面
+	if sent := draw(); len(sent) != 1 || len(sent[0]) != len(img.Pix) {
+		t.Fatalf("first draw: %d writes, want 1 full frame", len(sent))
+	}
+	if sent := draw(); len(sent) != 0 {
+		t.Errorf("unchanged frame: %d writes, want 0", len(sent))
+	}
+
+	tests := []struct {
+		name   string
+		pixels []image.Point
+		want   [][]int
+	}{
+		// page 2、第 10 欄
+		{"single pixel", []image.Point{{10, 20}}, [][]int{{2*128 + 10}}},
+		// 相距小於 mergeGap 的變化一起傳送
+		{"close pixels", []image.Point{{40, 0}, {43, 1}}, [][]int{{40, 43}}},
+		// 相距很遠的變化分別傳送
+		{"opposite corners", []image.Point{{0, 0}, {127, 63}}, [][]int{{0}, {7*128 + 127}}},
+	}
+	for _, tt := range tests {
+		for _, p := range tt.pixels {
+			img.SetBit(p.X, p.Y, !img.BitAt(p.X, p.Y))
+		}
+		if sent := draw(); !slices.EqualFunc(sent, tt.want, slices.Equal) {
+			t.Errorf("%s: sent bytes %v, want %v", tt.name, sent, tt.want)
+		}
+	}
+}
+
+// 旋轉與鏡像後，旋轉前畫面的四個角落對應到顯示器的哪個角落
+func TestDiffDisplayOrient(t *testing.T) {
+	tests := []struct {
+		rotate       int
+		flipH, flipV bool
+		corners      [4]string // 左上、右上、左下、右下對應的位置
+	}{
+		{0, false, false, [4]string{"TL", "TR", "BL", "BR"}},
+		{90, false, false, [4]string{"TR", "BR", "TL", "BL"}},
+		{180, false, false, [4]string{"BR", "BL", "TR", "TL"}},
+		{270, false, false, [4]string{"BL", "TL", "BR", "TR"}},
+		{0, true, false, [4]string{"TR", "TL", "BR", "BL"}},
+		{0, false, true, [4]string{"BL", "BR", "TL", "TR"}},
+		{0, true, true, [4]string{"BR", "BL", "TR", "TL"}},
+		{90, true, false, [4]string{"BR", "TR", "BL", "TL"}},
+		{90, false, true, [4]string{"TL", "BL", "TR", "BR"}},
+		{180, true, false, [4]string{"BL", "BR", "TL", "TR"}},
+		{270, true, false, [4]string{"TL", "BL", "TR", "BR"}},
+		{270, true, true, [4]string{"TR", "BR", "TL", "BL"}},
+	}
+	corner := func(r image.Rectangle, name string) image.Point {
+		p := image.Point{}
+		if name[0] == 'B' {
+			p.Y = r.Dy() - 1
+		}
+		if name[1] == 'R' {
+			p.X = r.Dx() - 1
+		}
+		return p
+	}
+	for _, size := range []image.Rectangle{image.Rect(0, 0, 128, 64), image.Rect(0, 0, 128, 32)} {
+		for _, tt := range tests {
+			d := newDiffDisplay(&recordingDriver{bounds: size}, tt.rotate, tt.flipH, tt.flipV)
+			src := image1bit.NewVerticalLSB(d.Bounds())
+			dst := image1bit.NewVerticalLSB(size)
+			for i, from := range []string{"TL", "TR", "BL", "BR"} {
+				clear(src.Pix)
+				p := corner(src.Rect, from)
+				src.SetBit(p.X, p.Y, true)
+				d.orient(dst, src)
+				want := corner(size, tt.corners[i])
+				if !dst.BitAt(want.X, want.Y) || bitCount(dst) != 1 {
+					t.Errorf("%dx%d rotate %d flipH %v flipV %v: %s not mapped to %s",
+						size.Dx(), size.Dy(), tt.rotate, tt.flipH, tt.flipV, from, tt.corners[i])
+				}
+			}
+		}
+	}
+
+	// 防烙印位移後超出畫面的像素不顯示
+	d := newDiffDisplay(&recordingDriver{bounds: image.Rect(0, 0, 128, 32)}, 90, false, false)
+	d.offset = image.Pt(1, 0)
+	src := image1bit.NewVerticalLSB(d.Bounds())
+	dst := image1bit.NewVerticalLSB(image.Rect(0, 0, 128, 32))
+	src.SetBit(0, 0, true)
+	src.SetBit(31, 0, true)
+	d.orient(dst, src)
+	// 旋轉後的左上角在顯示器右上角，往右位移變成往下一列
+	if !dst.BitAt(127, 1) || bitCount(dst) != 1 {
+		t.Error("offset (1, 0) rotate 90: top-left not at (127, 1) or right edge not dropped")
+	}
+}
+
+func bitCount(img *image1bit.VerticalLSB) int {
+	n := 0
+	for _, b := range img.Pix {
+		n += bits.OnesCount8(b)
+	}
+	return n
+}
@@ -0,0 +1,352 @@
+// 多個顯示器：每個顯示器有自己的頁面、切換時間與按鈕，共用同一組資料收集
+package main
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpioreg"
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+// 分頁等待期間檢查頁面是否被按鈕切換的間隔
+const holdTick = 50 * time.Millisecond
+
+// 顯示器與其顯示狀態，每個顯示器由各自的 goroutine 繪製
+type screen struct {
+	name    string // DISPLAYS 中的名稱，單一顯示器時為空白
+	prefix  string // 設定的前綴，例如 NET_
+	primary bool   // 第一個顯示器，LED 顯示它的循環狀態，按鈕有預設腳位
+
+	dev      *diffDisplay
+	img      *image1bit.VerticalLSB
+	closeBus func() error
+
+	// 按鈕的 goroutine 與繪製迴圈都會存取，以 mu 保護
+	mu            sync.Mutex
+	pages         []int // PAGES 設定的頁面，空白為全部
+	stepBy        int
+	backward      bool // 最後一次以按鈕往前切換頁面，切換效果反方向移動
+	onLoop        bool
+	sleepTime     time.Duration
+	originalSleep time.Duration
+	firstRun      bool
+	wrapped       bool // 回到第一頁後還沒有顯示任何頁面
+
+	buttons    [4]gpio.PinIO
+	buttonPage int
+
+	// 最後顯示的畫面與頁面，作為切換效果的起點
+	lastFrame *image1bit.VerticalLSB
+	lastPage  int
+	// 顯示器目前的對比，初始化時為最大值
+	contrast byte
+	// DISPLAY_INVERT 設定的反白，與防烙印輪流反白後目前的狀態，截圖時會讀取 inverted，以 mu 保護
+	invert   bool
+	inverted bool
+
+	// 最後一次按下按鈕的時間，用於螢幕保護程式，以 mu 保護
+	lastInput time.Time
+	// 顯示器關閉或只顯示時鐘，按下按鈕只喚醒不切換頁面，以 mu 保護
+	asleep bool
+	// 按下按鈕後暫停夜間模式到此時間，以 mu 保護
+	wakeUntil time.Time
+	// 最後一次以按鈕組合截圖的時間，兩個按鈕的 goroutine 都會存取，以 mu 保護
+	lastShot time.Time
+
+	// 目前畫面上的跑馬燈，與各跑馬燈開始的時間
+	marquees         []*marquee
+	marqueeStart     map[string]time.Time
+	prevMarqueeStart map[string]time.Time
+
+	// 需要持續更新的頁面，頁面停留期間每幀重新繪製
+	live func()
+
+	// RAM 頁面是否顯示明細
+	memDetailView bool
+}
+
+// 開啟 DISPLAYS 設定的所有顯示器，未設定時只開啟一個
+func openScreens() ([]*screen, error) {
+	names := displayNames()
+	var list []*screen
+	for i, name := range names {
+		s, err := newScreen(name, i == 0)
+		if err != nil {
+			for _, s := range list {
+				s.closeBus()
+			}
+			if name != "" {
+				return nil, fmt.Errorf("顯示器 %s: %w", name, err)
+			}
+			return nil, err
+		}
+		list = append(list, s)
+	}
+	return list, nil
+}
+
+// 開啟顯示器並載入設定
+func newScreen(name string, primary bool) (*screen, error) {
+	s := &screen{
+		name:             name,
+		primary:          primary,
+		firstRun:         true,
+		lastPage:         -1,
+		contrast:         0xFF,
+		lastInput:        time.Now(),
+		marqueeStart:     map[string]time.Time{},
+		prevMarqueeStart: map[string]time.Time{},
+	}
+	if name != "" {
+		s.prefix = strings.ToUpper(name) + "_"
+	}
+
+	// 型號、尺寸與位址由 .env 設定
+	cfg := loadDisplayConfig(s.prefix)
+	oled, closeBus, err := openDisplay(cfg)
+	if err != nil {
+		return nil, err
+	}
+	s.closeBus = closeBus
+	s.invert, s.inverted = cfg.Invert, cfg.Invert
+	if cfg.Invert {
+		if err := oled.Invert(true); err != nil {
+			closeBus()
+			return nil, err
+		}
+	}
+	// 只傳送有變化的區塊，旋轉與鏡像也在這裡處理
+	s.dev = newDiffDisplay(oled, cfg.Rotate, cfg.FlipH, cfg.FlipV)
+	s.img = image1bit.NewVerticalLSB(s.dev.Bounds())
+	s.loadConfig()
+	return s, nil
+}
+
+// 載入頁面、切換時間與按鈕設定，.env 變更時重新載入
+func (s *screen) loadConfig() {
+	onLoop := shouldOnLoop(s.prefix)
+	stepBy := defaultPage(s.prefix)
+	// 每次循環延遲時間
+	sleepTime := showSleepTime(s.prefix)
+	pages := screenPages(s.prefix)
+	var buttons [4]gpio.PinIO
+	for i := range buttons {
+		if name := buttonPinName(s.prefix, i+1, s.primary); name != "" {
+			buttons[i] = gpioreg.ByName(name)
+		}
+	}
+	buttonPage := setButtonPage(s.prefix)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onLoop = onLoop
+	s.stepBy = stepBy
+	s.sleepTime = sleepTime
+	s.originalSleep = sleepTime
+	s.pages = pages
+	s.buttons = buttons
+	s.buttonPage = buttonPage
+}
+
+// 日誌中顯示的名稱
+func (s *screen) String() string {
+	if s.name == "" {
+		return "display"
+	}
+	return s.name
+}
+
+// 此顯示器是否顯示第 page 頁
+func (s *screen) showsPage(page int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inPages(page)
+}
+
+// 第 page 頁是否在 PAGES 設定中，呼叫時需持有 mu
+func (s *screen) inPages(page int) bool {
+	return len(s.pages) == 0 || slices.Contains(s.pages, page)
+}
+
+// 按鈕 1 ~ 4 的腳位，未設定的按鈕為 nil
+func (s *screen) buttonPins() [4]gpio.PinIO {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buttons
+}
+
+// 切換到 BUTTON_PAGE 設定的頁面，回傳切換後的頁面
+func (s *screen) jumpToButtonPage() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stepBy = s.buttonPage
+	s.backward = false
+	return s.stepBy
+}
+
+// 目前的頁面
+func (s *screen) currentPage() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stepBy
+}
+
+// 切換到第 page 頁
+func (s *screen) setPage(page int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stepBy = page
+	s.backward = false
+}
+
+// 目前的頁面，與是否由上一頁切換而來
+func (s *screen) pageDir() (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stepBy, s.backward
+}
+
+// 跳過第 page 頁，期間已按下按鈕切換到其他頁面時不變
+func (s *screen) skipPage(page int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stepBy == page {
+		s.stepBy = page + 1
+	}
+}
+
+// 是否循環顯示
+func (s *screen) looping() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.onLoop
+}
+
+// 每頁停留的時間
+func (s *screen) pageTime() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sleepTime
+}
+
+// 分頁之間以短間隔等待 d，按鈕切換到其他頁面時提前回傳 false
+func (s *screen) holdPage(page int, d time.Duration) bool {
+	deadline := time.Now().Add(d)
+	for {
+		if s.currentPage() != page {
+			return false
+		}
+		left := time.Until(deadline)
+		if left <= 0 {
+			return true
+		}
+		time.Sleep(min(left, holdTick))
+	}
+}
+
+// 記錄按下按鈕的時間
+func (s *screen) touch() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastInput = time.Now()
+}
+
+// 按下按鈕：記錄時間並暫時解除夜間模式，顯示器關閉或顯示時鐘時回傳 true，這次按下不切換頁面
+// 在同一個鎖中讀取 asleep，避免自動關閉或螢幕保護程式看到按下後先結束而切換頁面
+func (s *screen) press() bool {
+	now := time.Now()
+	until := now.Add(nightWake(s.prefix))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastInput = now
+	s.wakeUntil = until
+	return s.asleep
+}
+
+// 距離最後一次按下按鈕的時間
+func (s *screen) idle() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Since(s.lastInput)
+}
+
+// 顯示器關閉或只顯示時鐘期間設為 true
+func (s *screen) setAsleep(asleep bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.asleep = asleep
+}
+
+// 往前 (dir > 0) 或往後 (dir < 0) 切換到下一個要顯示的頁面，回傳切換後的頁面
+func (s *screen) step(dir int) int {
+	end := pageCount()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	page := s.stepBy
+	for range end {
+		page += dir
+		if page > end {
+			page = 1
+		} else if page < 1 {
+			page = end
+		}
+		if s.inPages(page) {
+			break
+		}
+	}
+	s.stepBy = page
+	s.backward = dir < 0
+	return page
+}
+
+// 取 .env 檔案中的 DISPLAYS 設定，以逗號分隔的顯示器名稱
+func displayNames() []string {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	var names []string
+	for _, name := range strings.Split(envConfig["DISPLAYS"], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return []string{""} // 預設值：單一顯示器，設定沒有前綴
+	}
+	return names
+}
+
+// 取 .env 檔案中顯示器的設定，例如 NET_SLEEP_TIME，未設定時使用 SLEEP_TIME
+// 呼叫時需持有 configMutex
+func screenEnv(prefix, key string) string {
+	if value := envConfig[prefix+key]; value != "" {
+		return value
+	}
+	return envConfig[key]
+}
+
+// 取 .env 檔案中的 PAGES 設定，以逗號分隔的頁碼
+func screenPages(prefix string) []int {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	var pages []int
+	for _, field := range strings.Split(screenEnv(prefix, "PAGES"), ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		page, err := strconv.Atoi(field)
+		if err != nil || page <= 0 {
+			log.Printf("PAGES 的頁碼無效: %q", field)
+			continue
+		}
+		pages = append(pages, page)
+	}
+	return pages
+}
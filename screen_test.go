@@ -0,0 +1,167 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestScreenStep(t *testing.T) {
+	s := &screen{pages: []int{2, 5, 9}, stepBy: 5}
+	if got := s.step(1); got != 9 {
+		t.Errorf("step(1) from 5 = %d, want 9", got)
+	}
+	// 超出最後一頁時回到第一個要顯示的頁面
+	if got := s.step(1); got != 2 {
+		t.Errorf("step(1) from 9 = %d, want 2", got)
+	}
+	if got := s.step(-1); got != 9 {
+		t.Errorf("step(-1) from 2 = %d, want 9", got)
+	}
+}
+
+// 切換方向記錄在 step 中，從第一頁往前繞到最後一頁時仍是上一頁
+func TestScreenStepDirection(t *testing.T) {
+	s := &screen{stepBy: 1}
+	s.step(-1)
+	if page, backward := s.pageDir(); page != builtinPages || !backward {
+		t.Errorf("step(-1) from 1 = %d backward %v, want %d backward", page, backward, builtinPages)
+	}
+	s.step(1)
+	if page, backward := s.pageDir(); page != 1 || backward {
+		t.Errorf("step(1) from %d = %d backward %v, want 1 forward", builtinPages, page, backward)
+	}
+	s.step(-1)
+	s.setPage(3)
+	if _, backward := s.pageDir(); backward {
+		t.Error("setPage after step(-1): backward = true, want false")
+	}
+}
+
+// 版面檔案重新載入時頁數隨之改變，與按鈕同時進行
+func TestScreenStepLayoutReload(t *testing.T) {
+	defer func(pages []layoutPage) { layoutPages = pages }(layoutPages)
+	setPages := func(n int) {
+		layoutMutex.Lock()
+		layoutPages = make([]layoutPage, n)
+		layoutMutex.Unlock()
+	}
+	setPages(2)
+
+	s := &screen{stepBy: 1}
+	if got := s.step(-1); got != builtinPages+2 {
+		t.Errorf("step(-1) from 1 with 2 custom pages = %d, want %d", got, builtinPages+2)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := range 100 {
+			setPages(i % 3)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			if page := s.step(1); page < 1 || page > builtinPages+2 {
+				t.Errorf("step(1) = %d, want 1..%d", page, builtinPages+2)
+			}
+		}
+	}()
+	wg.Wait()
+}
+
+// 按鈕已切換頁面時，繪製迴圈跳過原本的頁面不會覆蓋按鈕的選擇
+func TestScreenSkipPage(t *testing.T) {
+	s := &screen{stepBy: 7}
+	s.skipPage(7)
+	if got := s.currentPage(); got != 8 {
+		t.Errorf("skipPage(7) = %d, want 8", got)
+	}
+	s.setPage(3)
+	s.skipPage(8)
+	if got := s.currentPage(); got != 3 {
+		t.Errorf("skipPage after button = %d, want 3", got)
+	}
+}
+
+// 按鈕的 goroutine 與繪製迴圈同時存取，以 go test -race 檢查
+func TestScreenConcurrentInput(t *testing.T) {
+	s := &screen{stepBy: 1, onLoop: true, sleepTime: time.Second, originalSleep: time.Second}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			s.press()
+			s.step(1)
+			s.toggleLoop()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			s.setAsleep(s.idle() > time.Hour)
+			s.skipPage(s.currentPage())
+			_ = s.looping()
+			_ = s.pageTime()
+		}
+	}()
+	wg.Wait()
+}
+
+// 分頁等待期間按鈕切換頁面時提前結束
+func TestScreenHoldPage(t *testing.T) {
+	s := &screen{stepBy: 8}
+	if !s.holdPage(8, 2*holdTick) {
+		t.Error("holdPage without button = false, want true")
+	}
+	go func() {
+		time.Sleep(holdTick)
+		s.setPage(9)
+	}()
+	start := time.Now()
+	if s.holdPage(8, time.Minute) {
+		t.Error("holdPage after button = true, want false")
+	}
+	if d := time.Since(start); d > 10*holdTick {
+		t.Errorf("holdPage returned after %v, want about %v", d, holdTick)
+	}
+}
+
+// .env 重新載入時按鈕的 goroutine 與繪製迴圈同時讀取頁面與按鈕設定
+func TestScreenReloadConcurrent(t *testing.T) {
+	defer func(cfg map[string]string) { envConfig = cfg }(envConfig)
+	envConfig = map[string]string{"PAGES": "2,5,9", "BUTTON_PAGE": "5"}
+
+	s := &screen{}
+	s.loadConfig()
+	if got := s.jumpToButtonPage(); got != 5 {
+		t.Errorf("jumpToButtonPage() = %d, want 5", got)
+	}
+	if s.showsPage(3) || !s.showsPage(9) {
+		t.Error("showsPage does not follow PAGES=2,5,9")
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			s.loadConfig()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			if page := s.step(1); page != 2 && page != 5 && page != 9 {
+				t.Errorf("step(1) = %d, want one of PAGES", page)
+			}
+			_ = s.showsPage(5)
+			_ = s.buttonPins()
+			s.jumpToButtonPage()
+		}
+	}()
+	wg.Wait()
+}
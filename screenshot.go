@@ -0,0 +1,231 @@
+// 截圖：將目前顯示的畫面放大存成 PNG，可由 HTTP、按鈕 1 + 2 或 screenshot 子命令取得
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const screenshotUsage = `用法: oled-status screenshot [選項]
+
+從執行中的 oled-status 取得目前的畫面，需要在 .env 設定 HTTP_LISTEN。
+
+選項:
+`
+
+// 目前顯示畫面的放大圖，反白顯示時一併反白
+func (s *screen) screenshot(scale int) *image.Gray {
+	scale = max(scale, 1)
+	b := s.dev.Bounds()
+	out := image.NewGray(image.Rect(0, 0, b.Dx()*scale, b.Dy()*scale))
+	if frame := s.dev.snapshot(); frame != nil {
+		drawScaled(out, frame, image.Point{}, scale)
+	}
+	if s.isInverted() {
+		for i, v := range out.Pix {
+			out.Pix[i] = 0xFF - v
+		}
+	}
+	return out
+}
+
+// 截圖存到 SCREENSHOT_DIR，檔名包含顯示器名稱與時間，回傳檔案路徑
+func (s *screen) saveScreenshot() (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, s.screenshot(screenshotScale())); err != nil {
+		return "", err
+	}
+	return writeScreenshot(buf.Bytes(), s.name)
+}
+
+// 寫入 SCREENSHOT_DIR，目錄不存在時建立
+func writeScreenshot(data []byte, name string) (string, error) {
+	dir := screenshotDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	file := "oled-" + time.Now().Format("20060102-150405") + ".png"
+	if name != "" {
+		file = "oled-" + name + "-" + time.Now().Format("20060102-150405") + ".png"
+	}
+	path := filepath.Join(dir, file)
+	return path, os.WriteFile(path, data, 0o644)
+}
+
+// 按下按鈕 1 或 2 後等待另一個按鈕的時間，期間也按下時視為同時按下
+const comboWindow = 150 * time.Millisecond
+
+// 同時按下按鈕 1 與 2 時截圖，回傳是否為組合鍵
+// 先按下的按鈕等待 comboWindow 才切換頁面，避免截圖前已經換頁
+func (s *screen) screenshotCombo(n int) bool {
+	if n != 1 && n != 2 {
+		return false
+	}
+	// 按鈕 1 對應按鈕 2，按鈕 2 對應按鈕 1
+	other := s.buttonPins()[2-n]
+	if other == nil {
+		return false
+	}
+	// 另一個按鈕的 goroutine 已經截圖
+	if s.sinceShot() < time.Second {
+		return true
+	}
+	for start := time.Now(); other.Read(); time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) >= comboWindow {
+			return false
+		}
+	}
+	// 兩個按鈕的 goroutine 都會偵測到，只截一次
+	if !s.claimShot() {
+		return true
+	}
+	path, err := s.saveScreenshot()
+	if err != nil {
+		log.Printf("截圖失敗: %v", err)
+		return true
+	}
+	log.Printf("截圖已儲存 %s", path)
+	return true
+}
+
+// 距離最後一次以按鈕組合截圖的時間
+func (s *screen) sinceShot() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Since(s.lastShot)
+}
+
+// 記錄截圖時間，一秒內已經截圖時回傳 false
+func (s *screen) claimShot() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Since(s.lastShot) < time.Second {
+		return false
+	}
+	s.lastShot = time.Now()
+	return true
+}
+
+// 依名稱尋找顯示器，空白為第一個
+func findScreen(name string) *screen {
+	if name == "" {
+		return screens[0]
+	}
+	for _, s := range screens {
+		if strings.EqualFold(s.name, name) {
+			return s
+		}
+	}
+	return nil
+}
+
+// HTTP 端點 GET /screenshot.png，display 指定顯示器名稱，scale 指定放大倍數
+func handleScreenshot(w http.ResponseWriter, r *http.Request) {
+	s := findScreen(r.URL.Query().Get("display"))
+	if s == nil {
+		http.Error(w, "找不到顯示器", http.StatusNotFound)
+		return
+	}
+	scale := screenshotScale()
+	if v, err := strconv.Atoi(r.URL.Query().Get("scale")); err == nil && v > 0 && v <= 16 {
+		scale = v
+	}
+	w.Header().Set("Content-Type", "image/png")
+	if err := png.Encode(w, s.screenshot(scale)); err != nil {
+		log.Println("截圖輸出失敗:", err)
+	}
+}
+
+// 執行 screenshot 子命令，經由 HTTP 端點取得執行中程式的畫面
+func runScreenshot(args []string) error {
+	envConfig = loadEnv()
+
+	fs := flag.NewFlagSet("screenshot", flag.ContinueOnError)
+	output := fs.String("o", "", "輸出檔案，未指定時存到 SCREENSHOT_DIR")
+	display := fs.String("display", "", "顯示器名稱，未指定時為第一個")
+	scale := fs.Int("scale", screenshotScale(), "放大倍數 1 ~ 16")
+	addr := fs.String("addr", httpListen(), "執行中程式的 HTTP 位址")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), screenshotUsage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if *addr == "" {
+		return errors.New("需要在 .env 設定 HTTP_LISTEN 或指定 -addr")
+	}
+
+	// :9101 這類只有埠號的位址連線到本機
+	host := *addr
+	if strings.HasPrefix(host, ":") {
+		host = "localhost" + host
+	}
+	query := url.Values{"scale": {strconv.Itoa(*scale)}}
+	if *display != "" {
+		query.Set("display", *display)
+	}
+	client := http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get("http://" + host + "/screenshot.png?" + query.Encode())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	path := *output
+	if path == "" {
+		path, err = writeScreenshot(data, *display)
+	} else {
+		err = os.WriteFile(path, data, 0o644)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println(path)
+	return nil
+}
+
+// 取 .env 檔案中的 SCREENSHOT_DIR 設定
+func screenshotDir() string {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	dir := strings.TrimSpace(envConfig["SCREENSHOT_DIR"])
+	if dir == "" {
+		dir = "screenshots" // 預設值
+	}
+	return dir
+}
+
+// 取 .env 檔案中的 SCREENSHOT_SCALE 設定，PNG 的放大倍數
+func screenshotScale() int {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	scale, err := strconv.Atoi(envConfig["SCREENSHOT_SCALE"])
+	if err != nil || scale <= 0 || scale > 16 {
+		return 4 // 預設值
+	}
+	return scale
+}
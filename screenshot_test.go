@@ -0,0 +1,139 @@
+package main
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/conntest"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+)
+
+// 有按鈕 1、2 的顯示器，截圖存到暫存目錄
+func newComboScreen(t *testing.T) (*screen, *gpiotest.Pin, *gpiotest.Pin, string) {
+	t.Helper()
+	oled, err := newSH1106(&conntest.Record{}, nil, 128, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	cfg := envConfig
+	envConfig = map[string]string{"SCREENSHOT_DIR": dir}
+	t.Cleanup(func() { envConfig = cfg })
+
+	b1 := &gpiotest.Pin{N: "B1", L: gpio.High}
+	b2 := &gpiotest.Pin{N: "B2", L: gpio.High}
+	s := &screen{dev: newDiffDisplay(oled, 0, false, false)}
+	s.buttons[0], s.buttons[1] = b1, b2
+	return s, b1, b2, dir
+}
+
+func countFiles(t *testing.T, dir string) int {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(entries)
+}
+
+// 單獨按下時等待後不視為組合鍵
+func TestScreenshotComboSingle(t *testing.T) {
+	s, b1, _, dir := newComboScreen(t)
+	b1.Out(gpio.Low)
+	start := time.Now()
+	if s.screenshotCombo(1) {
+		t.Error("button 1 alone: combo = true, want false")
+	}
+	if d := time.Since(start); d < comboWindow {
+		t.Errorf("returned after %v, want to wait %v for button 2", d, comboWindow)
+	}
+	if n := countFiles(t, dir); n != 0 {
+		t.Errorf("%d screenshots, want 0", n)
+	}
+	// 按鈕 3、4 不等待
+	if s.screenshotCombo(3) {
+		t.Error("button 3: combo = true, want false")
+	}
+}
+
+// 先按下的按鈕稍後才按下另一個，兩個按鈕的 goroutine 都不切換頁面，只截一次
+func TestScreenshotComboLate(t *testing.T) {
+	s, b1, b2, dir := newComboScreen(t)
+	b1.Out(gpio.Low)
+
+	var wg sync.WaitGroup
+	results := make([]bool, 2)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		results[0] = s.screenshotCombo(1)
+	}()
+	go func() {
+		defer wg.Done()
+		time.Sleep(comboWindow / 3)
+		b2.Out(gpio.Low)
+		// 按鈕 2 的防彈跳延遲後，按鈕 1 已經放開
+		time.Sleep(comboWindow / 3)
+		b1.Out(gpio.High)
+		results[1] = s.screenshotCombo(2)
+	}()
+	wg.Wait()
+
+	if !results[0] || !results[1] {
+		t.Errorf("combo = %v, want both true", results)
+	}
+	if n := countFiles(t, dir); n != 1 {
+		t.Errorf("%d screenshots, want 1", n)
+	}
+}
+
+// 防烙印輪流反白時 HTTP 同時截圖，以 go test -race 檢查，截圖與顯示器同樣反白
+func TestScreenshotInverted(t *testing.T) {
+	s, _, _, _ := newComboScreen(t)
+	cfg := burnInConfig{InvertInterval: time.Second}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range 50 {
+			s.applyBurnIn(cfg, time.Unix(int64(i), 0))
+		}
+	}()
+	for range 50 {
+		s.screenshot(1)
+	}
+	wg.Wait()
+
+	s.applyBurnIn(cfg, time.Unix(1, 0))
+	if got := s.screenshot(1).Pix[0]; got != 0xFF {
+		t.Errorf("inverted screenshot pixel = %#x, want 0xff", got)
+	}
+	s.applyBurnIn(cfg, time.Unix(2, 0))
+	if got := s.screenshot(1).Pix[0]; got != 0 {
+		t.Errorf("normal screenshot pixel = %#x, want 0", got)
+	}
+}
+
+// 休眠中按下只喚醒，不等待組合鍵也不截圖
+func TestScreenshotComboAsleep(t *testing.T) {
+	s, b1, b2, dir := newComboScreen(t)
+	s.stepBy = 3
+	s.setAsleep(true)
+	b1.Out(gpio.Low)
+	b2.Out(gpio.Low)
+
+	start := time.Now()
+	s.handlePress(1)
+	if d := time.Since(start); d >= comboWindow {
+		t.Errorf("waking press returned after %v, want no combo wait", d)
+	}
+	if n := countFiles(t, dir); n != 0 {
+		t.Errorf("%d screenshots, want 0", n)
+	}
+	if got := s.currentPage(); got != 3 {
+		t.Errorf("page = %d after waking press, want 3", got)
+	}
+}
@@ -0,0 +1,185 @@
+// SH1106 驅動程式：1.3 吋 OLED 常用的控制器，內部為 132 欄，畫面從第 2 欄開始
+package main
+
+import (
+	"errors"
+	"fmt"
+	"image"
+
+	"golang.org/x/image/draw"
+	"periph.io/x/conn/v3"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+// 132 欄的記憶體中，128 欄畫面的起始位置
+const sh1106ColumnOffset = 2
+
+// SH1106 顯示器
+type sh1106Dev struct {
+	c      conn.Conn
+	dc     gpio.PinOut // SPI 的 Data/Command 腳位，I2C 為 nil
+	rect   image.Rectangle
+	buffer []byte // 顯示器上目前的內容，格式與 image1bit.VerticalLSB.Pix 相同
+	next   *image1bit.VerticalLSB
+	halted bool
+	inited bool // 第一次繪製時傳送整個畫面
+}
+
+// 建立 SH1106，I2C 的 c 為已設定位址的 i2c.Dev，dc 為 nil
+// SPI 的 c 為 spi.Conn，dc 為 Data/Command 腳位
+func newSH1106(c conn.Conn, dc gpio.PinOut, w, h int) (*sh1106Dev, error) {
+	if w <= 0 || w > 132-sh1106ColumnOffset || h <= 0 || h > 64 || h%8 != 0 {
+		return nil, fmt.Errorf("sh1106: invalid size %dx%d", w, h)
+	}
+	d := &sh1106Dev{
+		c:      c,
+		dc:     dc,
+		rect:   image.Rect(0, 0, w, h),
+		buffer: make([]byte, w*h/8),
+	}
+	comPins := byte(0x12)
+	if h <= 32 {
+		comPins = 0x02
+	}
+	err := d.sendCommand([]byte{
+		0xAE,       // Display off
+		0xD5, 0x80, // 振盪頻率
+		0xA8, byte(h - 1), // 掃描行數
+		0xD3, 0x00, // 顯示位移
+		0x40,       // 起始行
+		0xAD, 0x8B, // 內建 DC-DC 開啟
+		0xA1,          // 左右反轉，與 SSD1306 預設方向相同
+		0xC8,          // 上下反轉
+		0xDA, comPins, // COM 腳位配置
+		0x81, 0xFF, // 最大對比
+		0xD9, 0x1F, // 預充電週期
+		0xDB, 0x40, // VCOMH
+		0xA4, // 依記憶體內容顯示
+		0xA6, // 正常顯示
+		0xAF, // Display on
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *sh1106Dev) String() string {
+	return fmt.Sprintf("sh1106.Dev{%s, %s}", d.c, d.rect.Max)
+}
+
+func (d *sh1106Dev) Bounds() image.Rectangle {
+	return d.rect
+}
+
+// 繪製到畫面，合成後交給 Write 傳送
+func (d *sh1106Dev) Draw(r image.Rectangle, src image.Image, sp image.Point) error {
+	if img, ok := src.(*image1bit.VerticalLSB); ok && r == d.rect && img.Rect == d.rect && sp == (image.Point{}) {
+		_, err := d.Write(img.Pix)
+		return err
+	}
+	if d.next == nil {
+		d.next = image1bit.NewVerticalLSB(d.rect)
+	}
+	copy(d.next.Pix, d.buffer)
+	draw.Src.Draw(d.next, r, src, sp)
+	_, err := d.Write(d.next.Pix)
+	return err
+}
+
+// 寫入整個畫面，每個 page 只傳送有變化的欄位
+// SH1106 不支援水平定址模式，每個 page 需要分別設定位址
+func (d *sh1106Dev) Write(pixels []byte) (int, error) {
+	if len(pixels) != len(d.buffer) {
+		return 0, fmt.Errorf("sh1106: invalid pixel stream length; expected %d bytes, got %d bytes", len(d.buffer), len(pixels))
+	}
+	width := d.rect.Dx()
+	for page := range d.rect.Dy() / 8 {
+		row := page * width
+		start, end := 0, width
+		if d.inited {
+			for start < end && d.buffer[row+start] == pixels[row+start] {
+				start++
+			}
+			for end > start && d.buffer[row+end-1] == pixels[row+end-1] {
+				end--
+			}
+			if start == end {
+				continue
+			}
+		}
+		col := byte(start + sh1106ColumnOffset)
+		if err := d.sendCommand([]byte{0xB0 | byte(page), col & 0x0F, 0x10 | col>>4}); err != nil {
+			return 0, err
+		}
+		if err := d.sendData(pixels[row+start : row+end]); err != nil {
+			return 0, err
+		}
+		copy(d.buffer[row+start:row+end], pixels[row+start:row+end])
+	}
+	d.inited = true
+	return len(pixels), nil
+}
+
+// 關閉顯示，之後的任何指令都會重新開啟
+func (d *sh1106Dev) Halt() error {
+	if err := d.sendCommand([]byte{0xAE}); err != nil {
+		return err
+	}
+	d.halted = true
+	return nil
+}
+
+func (d *sh1106Dev) SetContrast(level byte) error {
+	return d.sendCommand([]byte{0x81, level})
+}
+
+func (d *sh1106Dev) Invert(blackOnWhite bool) error {
+	if blackOnWhite {
+		return d.sendCommand([]byte{0xA7})
+	}
+	return d.sendCommand([]byte{0xA6})
+}
+
+// 傳送資料，I2C 以 0x40 開頭，SPI 將 DC 設為 High
+func (d *sh1106Dev) sendData(c []byte) error {
+	if err := d.wake(); err != nil {
+		return err
+	}
+	if d.dc != nil {
+		if err := d.dc.Out(gpio.High); err != nil {
+			return err
+		}
+		return d.c.Tx(c, nil)
+	}
+	return d.c.Tx(append([]byte{0x40}, c...), nil)
+}
+
+// 傳送指令，I2C 以 0x00 開頭，SPI 將 DC 設為 Low
+func (d *sh1106Dev) sendCommand(c []byte) error {
+	if len(c) == 0 {
+		return errors.New("sh1106: empty command")
+	}
+	if c[0] != 0xAE {
+		if err := d.wake(); err != nil {
+			return err
+		}
+	}
+	if d.dc != nil {
+		if err := d.dc.Out(gpio.Low); err != nil {
+			return err
+		}
+		return d.c.Tx(c, nil)
+	}
+	return d.c.Tx(append([]byte{0x00}, c...), nil)
+}
+
+// 關閉顯示後重新開啟
+func (d *sh1106Dev) wake() error {
+	if !d.halted {
+		return nil
+	}
+	d.halted = false
+	return d.sendCommand([]byte{0xAF})
+}
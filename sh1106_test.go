@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"periph.io/x/conn/v3/conntest"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+	"periph.io/x/conn/v3/spi/spitest"
+)
+
+// 記錄每次改變 DC 腳位時 SPI 已傳送的次數，用來判斷每次傳送時 DC 的狀態
+type dcRecorder struct {
+	*gpiotest.Pin
+	rec     *spitest.Record
+	changes []dcChange
+}
+
+type dcChange struct {
+	op    int
+	level gpio.Level
+}
+
+func newDCRecorder(rec *spitest.Record) *dcRecorder {
+	return &dcRecorder{Pin: &gpiotest.Pin{N: "DC", L: gpio.High}, rec: rec}
+}
+
+func (p *dcRecorder) Out(l gpio.Level) error {
+	p.rec.Lock()
+	op := len(p.rec.Ops)
+	p.rec.Unlock()
+	p.changes = append(p.changes, dcChange{op, l})
+	return p.Pin.Out(l)
+}
+
+// 第 op 次傳送時 DC 的狀態
+func (p *dcRecorder) levelAt(op int) gpio.Level {
+	level := gpio.High
+	for _, c := range p.changes {
+		if c.op > op {
+			break
+		}
+		level = c.level
+	}
+	return level
+}
+
+// 傳送的內容與 DC 狀態，指令為 C，資料為 D
+type spiOp struct {
+	kind byte
+	w    []byte
+}
+
+func spiOps(rec *spitest.Record, dc *dcRecorder) []spiOp {
+	rec.Lock()
+	defer rec.Unlock()
+	ops := make([]spiOp, len(rec.Ops))
+	for i, io := range rec.Ops {
+		kind := byte('C')
+		if dc.levelAt(i) == gpio.High {
+			kind = 'D'
+		}
+		ops[i] = spiOp{kind, io.W}
+	}
+	return ops
+}
+
+func TestSH1106SPI(t *testing.T) {
+	rec := &spitest.Record{}
+	dc := newDCRecorder(rec)
+	dev, err := newSPIDriver(displayConfig{Model: "sh1106", W: 128, H: 64}, rec, dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 初始化只有一個指令，SPI 沒有 0x00 前綴
+	ops := spiOps(rec, dc)
+	if len(ops) != 1 || ops[0].kind != 'C' || ops[0].w[0] != 0xAE || ops[0].w[len(ops[0].w)-1] != 0xAF {
+		t.Fatalf("init ops = %v, want one command from 0xAE to 0xAF", ops)
+	}
+
+	// 第一次寫入：每個 page 設定位址後傳送 128 欄資料
+	frame := make([]byte, 128*64/8)
+	for i := range frame {
+		frame[i] = byte(i)
+	}
+	if _, err := dev.Write(frame); err != nil {
+		t.Fatal(err)
+	}
+	ops = spiOps(rec, dc)[1:]
+	if len(ops) != 16 {
+		t.Fatalf("first write: %d ops, want 16", len(ops))
+	}
+	for page := range 8 {
+		cmd, data := ops[page*2], ops[page*2+1]
+		if want := []byte{0xB0 | byte(page), 0x02, 0x10}; cmd.kind != 'C' || !bytes.Equal(cmd.w, want) {
+			t.Errorf("page %d address = %c % x, want C % x", page, cmd.kind, cmd.w, want)
+		}
+		if want := frame[page*128 : page*128+128]; data.kind != 'D' || !bytes.Equal(data.w, want) {
+			t.Errorf("page %d data = %c %d bytes, want D 128 bytes", page, data.kind, len(data.w))
+		}
+	}
+
+	// 之後只傳送有變化的欄位，欄位加上 2 欄位移
+	frame[3*128+20] ^= 0xFF
+	frame[3*128+21] ^= 0xFF
+	before := len(rec.Ops)
+	if _, err := dev.Write(frame); err != nil {
+		t.Fatal(err)
+	}
+	ops = spiOps(rec, dc)[before:]
+	want := []spiOp{
+		{'C', []byte{0xB3, 22 & 0x0F, 0x10 | 22>>4}},
+		{'D', frame[3*128+20 : 3*128+22]},
+	}
+	if !equalOps(ops, want) {
+		t.Errorf("partial write = %v, want %v", ops, want)
+	}
+
+	// 關閉後的指令先重新開啟顯示
+	before = len(rec.Ops)
+	if err := dev.Halt(); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.SetContrast(0x10); err != nil {
+		t.Fatal(err)
+	}
+	ops = spiOps(rec, dc)[before:]
+	want = []spiOp{{'C', []byte{0xAE}}, {'C', []byte{0xAF}}, {'C', []byte{0x81, 0x10}}}
+	if !equalOps(ops, want) {
+		t.Errorf("halt and contrast = %v, want %v", ops, want)
+	}
+}
+
+// I2C 沒有 DC 腳位，以 0x00 / 0x40 前綴區分指令與資料
+func TestSH1106I2C(t *testing.T) {
+	rec := &conntest.Record{}
+	dev, err := newSH1106(rec, nil, 128, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dev.Write(make([]byte, 128*32/8)); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.Invert(true); err != nil {
+		t.Fatal(err)
+	}
+	// 初始化、4 個 page 的位址與資料、反白
+	if len(rec.Ops) != 1+4*2+1 {
+		t.Fatalf("%d ops, want 10", len(rec.Ops))
+	}
+	if w := rec.Ops[0].W; w[0] != 0x00 || w[1] != 0xAE {
+		t.Errorf("init = % x, want 00 ae ...", w[:2])
+	}
+	if w := rec.Ops[1].W; !bytes.Equal(w, []byte{0x00, 0xB0, 0x02, 0x10}) {
+		t.Errorf("page address = % x, want 00 b0 02 10", w)
+	}
+	if w := rec.Ops[2].W; w[0] != 0x40 || len(w) != 129 {
+		t.Errorf("page data = % x... (%d bytes), want 40 and 128 bytes", w[:1], len(w))
+	}
+	if w := rec.Ops[9].W; !bytes.Equal(w, []byte{0x00, 0xA7}) {
+		t.Errorf("invert = % x, want 00 a7", w)
+	}
+}
+
+func TestSH1106InvalidSize(t *testing.T) {
+	for _, size := range [][2]int{{0, 64}, {131, 64}, {128, 72}, {128, 30}} {
+		if _, err := newSH1106(&conntest.Record{}, nil, size[0], size[1]); err == nil {
+			t.Errorf("%dx%d: error = nil, want invalid size", size[0], size[1])
+		}
+	}
+}
+
+func equalOps(a, b []spiOp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].kind != b[i].kind || !bytes.Equal(a[i].w, b[i].w) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,212 @@
+// NVMe / SD 卡 健康狀態
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// 儲存裝置健康資訊，數值未知時為 -1
+type storageHealth struct {
+	NVMeTemp  float64 // NVMe 溫度 (°C)
+	NVMeUsed  int     // NVMe 壽命已使用百分比
+	NVMeSpare int     // NVMe 可用備用區塊百分比
+	SDLife    int     // SD/eMMC 壽命已使用百分比上限
+	SDPreEOL  string  // SD/eMMC 壽命預警：Normal、Warning、Urgent
+}
+
+// smartctl -j 輸出中需要的欄位
+type smartctlOutput struct {
+	Temperature struct {
+		Current *float64 `json:"current"`
+	} `json:"temperature"`
+	NVMeLog *struct {
+		PercentageUsed *int `json:"percentage_used"`
+		AvailableSpare *int `json:"available_spare"`
+	} `json:"nvme_smart_health_information_log"`
+}
+
+// 獲取 儲存裝置 健康狀態
+func getStorageHealth() storageHealth {
+	h := storageHealth{NVMeTemp: -1, NVMeUsed: -1, NVMeSpare: -1, SDLife: -1}
+
+	h.NVMeTemp = getNVMeTemperature(nvmeDevice)
+
+	// smartctl 可取得溫度以外的壽命資訊
+	if smartctlCmd != "" {
+		if data, err := runSmartctl(smartctlCmd); err == nil {
+			parseSmartctl(data, &h)
+		}
+	}
+
+	h.SDLife, h.SDPreEOL = getSDLife(sdDevice)
+	return h
+}
+
+// 從 sysfs hwmon 讀取 NVMe 溫度
+func getNVMeTemperature(device string) float64 {
+	patterns := []string{
+		filepath.Join("/sys/class/nvme", device, "hwmon*", "temp1_input"),
+		filepath.Join("/sys/class/nvme", device, "device", "hwmon", "hwmon*", "temp1_input"),
+	}
+	for _, pattern := range patterns {
+		matches, _ := filepath.Glob(pattern)
+		for _, m := range matches {
+			if temp, ok := readMilliCelsius(m); ok {
+				return temp
+			}
+		}
+	}
+
+	// 找不到指定裝置時，使用名稱為 nvme 的 hwmon
+	names, _ := filepath.Glob("/sys/class/hwmon/hwmon*/name")
+	for _, n := range names {
+		content, err := os.ReadFile(n)
+		if err != nil || strings.TrimSpace(string(content)) != "nvme" {
+			continue
+		}
+		if temp, ok := readMilliCelsius(filepath.Join(filepath.Dir(n), "temp1_input")); ok {
+			return temp
+		}
+	}
+	return -1
+}
+
+// 讀取以千分之一度為單位的溫度檔案
+func readMilliCelsius(path string) (float64, bool) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	value, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil {
+		return 0, false
+	}
+	return float64(value) / 1000.0, true
+}
+
+// 執行 smartctl -j 並回傳輸出
+func runSmartctl(command string) ([]byte, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("empty smartctl command")
+	}
+	// smartctl 的結束碼是位元旗標，有輸出時仍嘗試解析
+	data, err := exec.Command(args[0], args[1:]...).Output()
+	if len(data) == 0 {
+		return nil, err
+	}
+	return data, nil
+}
+
+// 解析 smartctl -j 的輸出，沒有的欄位維持原值
+func parseSmartctl(data []byte, h *storageHealth) error {
+	var out smartctlOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		return err
+	}
+	if out.Temperature.Current != nil && h.NVMeTemp < 0 {
+		h.NVMeTemp = *out.Temperature.Current
+	}
+	if out.NVMeLog != nil {
+		if out.NVMeLog.PercentageUsed != nil {
+			h.NVMeUsed = *out.NVMeLog.PercentageUsed
+		}
+		if out.NVMeLog.AvailableSpare != nil {
+			h.NVMeSpare = *out.NVMeLog.AvailableSpare
+		}
+	}
+	return nil
+}
+
+// 從 sysfs 讀取 SD/eMMC 壽命資訊，SD 卡通常沒有這些檔案
+func getSDLife(device string) (int, string) {
+	base := filepath.Join("/sys/block", device, "device")
+	lifeTime, _ := os.ReadFile(filepath.Join(base, "life_time"))
+	preEOL, _ := os.ReadFile(filepath.Join(base, "pre_eol_info"))
+	return parseSDLife(string(lifeTime), string(preEOL))
+}
+
+// 解析 life_time 與 pre_eol_info 的內容，檔案不存在時傳入空字串
+func parseSDLife(lifeTime, preEOLInfo string) (int, string) {
+	life := -1
+	// 例如 "0x01 0x02"，每級代表 10% 的壽命
+	for _, f := range strings.Fields(lifeTime) {
+		v, err := strconv.ParseInt(f, 0, 64)
+		if err != nil || v <= 0 {
+			continue
+		}
+		life = max(life, int(v)*10)
+	}
+
+	preEOL := ""
+	v, _ := strconv.ParseInt(strings.TrimSpace(preEOLInfo), 0, 64)
+	switch v {
+	case 1:
+		preEOL = "Normal"
+	case 2:
+		preEOL = "Warning"
+	case 3:
+		preEOL = "Urgent"
+	}
+	return life, preEOL
+}
+
+// 儲存裝置警示檢查
+func checkStorageAlerts() {
+	if !showStorage {
+		setAlert("storage", false, "")
+		return
+	}
+	h := getStorageHealth()
+	var msgs []string
+	if storageTempAlert > 0 && h.NVMeTemp >= storageTempAlert {
+		msgs = append(msgs, fmt.Sprintf("NVMe %.0fC", h.NVMeTemp))
+	}
+	if storageWearAlert > 0 && h.NVMeUsed >= storageWearAlert {
+		msgs = append(msgs, fmt.Sprintf("NVMe wear %d%%", h.NVMeUsed))
+	}
+	if storageWearAlert > 0 && h.SDLife >= storageWearAlert {
+		msgs = append(msgs, fmt.Sprintf("SD wear %d%%", h.SDLife))
+	}
+	if h.SDPreEOL == "Warning" || h.SDPreEOL == "Urgent" {
+		msgs = append(msgs, "SD EOL "+h.SDPreEOL)
+	}
+	setAlert("storage", len(msgs) > 0, strings.Join(msgs, ", "))
+}
+
+// 取 .env 檔案中的 儲存裝置 健康狀態設定
+func shouldShowStorage() (bool, string, string, string) {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	showStorageStr := envConfig["SHOW_STORAGE"]
+	nvmeStr := envConfig["NVME_DEVICE"]
+	if nvmeStr == "" {
+		nvmeStr = "nvme0" // 預設值
+	}
+	sdStr := envConfig["SD_DEVICE"]
+	if sdStr == "" {
+		sdStr = "mmcblk0" // 預設值
+	}
+	return strings.ToLower(showStorageStr) == "true", nvmeStr, envConfig["SMARTCTL_CMD"], sdStr
+}
+
+// 取 .env 檔案中的 儲存裝置 警示門檻，0 表示不警示
+func storageAlertLimits() (float64, int) {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	tempLimit, err := strconv.ParseFloat(envConfig["STORAGE_TEMP_ALERT"], 64)
+	if err != nil {
+		tempLimit = 70 // 預設值
+	}
+	wearLimit, err := strconv.Atoi(envConfig["STORAGE_WEAR_ALERT"])
+	if err != nil {
+		wearLimit = 90 // 預設值
+	}
+	return tempLimit, wearLimit
+}
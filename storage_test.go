@@ -0,0 +1,75 @@
+package main
+
+import "testing"
+
+// smartctl -j -a /dev/nvme0 輸出的節錄
+const smartctlNVMe = `{
+  "json_format_version": [1, 0],
+  "smartctl": {"version": [7, 3], "exit_status": 0},
+  "device": {"name": "/dev/nvme0", "type": "nvme", "protocol": "NVMe"},
+  "model_name": "Samsung SSD 980 500GB",
+  "temperature": {"current": 41},
+  "nvme_smart_health_information_log": {
+    "critical_warning": 0,
+    "temperature": 41,
+    "available_spare": 100,
+    "available_spare_threshold": 10,
+    "percentage_used": 3,
+    "power_on_hours": 1234
+  }
+}`
+
+// USB 轉接的 SATA 裝置沒有 NVMe 健康資訊
+const smartctlSATA = `{
+  "smartctl": {"version": [7, 3], "exit_status": 4},
+  "device": {"name": "/dev/sda", "type": "sat", "protocol": "ATA"},
+  "temperature": {"current": 35}
+}`
+
+func TestParseSmartctl(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		temp float64
+		want storageHealth
+	}{
+		{"nvme", smartctlNVMe, -1, storageHealth{NVMeTemp: 41, NVMeUsed: 3, NVMeSpare: 100}},
+		{"sysfs temperature kept", smartctlNVMe, 39.5, storageHealth{NVMeTemp: 39.5, NVMeUsed: 3, NVMeSpare: 100}},
+		{"no life attribute", smartctlSATA, -1, storageHealth{NVMeTemp: 35, NVMeUsed: -1, NVMeSpare: -1}},
+	}
+	for _, tt := range tests {
+		h := storageHealth{NVMeTemp: tt.temp, NVMeUsed: -1, NVMeSpare: -1}
+		if err := parseSmartctl([]byte(tt.data), &h); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if h != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, h, tt.want)
+		}
+	}
+
+	h := storageHealth{NVMeTemp: -1, NVMeUsed: -1, NVMeSpare: -1}
+	if err := parseSmartctl([]byte("smartctl: command not found"), &h); err == nil {
+		t.Error("non-JSON output: error = nil")
+	}
+}
+
+func TestParseSDLife(t *testing.T) {
+	tests := []struct {
+		name             string
+		lifeTime, preEOL string
+		wantLife         int
+		wantPreEOL       string
+	}{
+		{"emmc", "0x01 0x02\n", "0x01\n", 20, "Normal"},
+		{"emmc worn", "0x0a 0x09\n", "0x03\n", 100, "Urgent"},
+		{"type A not reported", "0x00 0x03\n", "0x02\n", 30, "Warning"},
+		{"no life attribute", "", "", -1, ""},
+	}
+	for _, tt := range tests {
+		life, preEOL := parseSDLife(tt.lifeTime, tt.preEOL)
+		if life != tt.wantLife || preEOL != tt.wantPreEOL {
+			t.Errorf("%s: got %d %q, want %d %q", tt.name, life, preEOL, tt.wantLife, tt.wantPreEOL)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// systemd 服務狀態
+package main
+
+import (
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+)
+
+// 服務狀態
+type unitState struct {
+	Name   string
+	Active string // active、inactive、failed、activating ...
+	Sub    string // running、exited、dead ...
+}
+
+// 服務是否正常，啟動中也視為正常
+func (u unitState) ok() bool {
+	return u.Active == "active" || u.Active == "activating" || u.Active == "reloading"
+}
+
+// 頁面上顯示的狀態：OK、FAILED、OFF，無法讀取時為 N/A
+func (u unitState) label() string {
+	switch {
+	case u.ok():
+		return "OK"
+	case u.Active == "failed":
+		return "FAILED"
+	case u.Active == "inactive" || u.Active == "deactivating":
+		return "OFF"
+	}
+	return "N/A"
+}
+
+// 獲取 設定的 systemd 服務狀態
+func getUnitStates(units []string) []unitState {
+	states := make([]unitState, 0, len(units))
+	for _, unit := range units {
+		state, err := busctlUnitState(unit)
+		if err != nil {
+			// 沒有 busctl 或 D-Bus 無法連線時改用 systemctl
+			state, err = systemctlUnitState(unit)
+		}
+		if err != nil {
+			log.Printf("讀取服務 %s 狀態失敗: %v", unit, err)
+			state = unitState{Name: unit, Active: "unknown"}
+		}
+		states = append(states, state)
+	}
+	return states
+}
+
+// 經由 D-Bus (busctl) 讀取服務狀態
+func busctlUnitState(unit string) (unitState, error) {
+	state := unitState{Name: unit}
+	path := "/org/freedesktop/systemd1/unit/" + escapeBusPath(unit)
+	for _, prop := range []string{"ActiveState", "SubState"} {
+		out, err := exec.Command("busctl", "--system", "get-property",
+			"org.freedesktop.systemd1", path, "org.freedesktop.systemd1.Unit", prop).Output()
+		if err != nil {
+			return state, err
+		}
+		// 輸出格式為 s "active"
+		value := strings.Trim(strings.TrimPrefix(strings.TrimSpace(string(out)), "s "), `"`)
+		if prop == "ActiveState" {
+			state.Active = value
+		} else {
+			state.Sub = value
+		}
+	}
+	return state, nil
+}
+
+// D-Bus 物件路徑跳脫，英數字以外的字元轉為 _xx
+func escapeBusPath(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9' && i > 0) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "_%02x", c)
+		}
+	}
+	return b.String()
+}
+
+// 經由 systemctl show 讀取服務狀態
+func systemctlUnitState(unit string) (unitState, error) {
+	state := unitState{Name: unit}
+	out, err := exec.Command("systemctl", "show", "-p", "ActiveState", "-p", "SubState", unit).Output()
+	if err != nil {
+		return state, err
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "ActiveState":
+			state.Active = value
+		case "SubState":
+			state.Sub = value
+		}
+	}
+	if state.Active == "" {
+		return state, fmt.Errorf("no ActiveState for %s", unit)
+	}
+	return state, nil
+}
+
+// 服務警示檢查
+func checkSystemdAlerts() {
+	if !showSystemd || len(systemdUnits) == 0 {
+		setAlert("systemd", false, "")
+		return
+	}
+	var failed []string
+	for _, state := range getUnitStates(systemdUnits) {
+		if !state.ok() {
+			failed = append(failed, state.Name+" "+state.Active)
+		}
+	}
+	setAlert("systemd", len(failed) > 0, strings.Join(failed, ", "))
+}
+
+// 分頁顯示服務狀態，每頁 3 個服務（精簡版面 1 個），最後一頁由主循環更新顯示
+// 按鈕切換到其他頁面時回傳 false
+func (s *screen) displayUnitPages(page int, states []unitState) bool {
+	const lineHeight = 11
+	img := s.img
+	rows := itemLines(img)
+	linesPerPage := max(contentLines(img)/rows, 1)
+
+	pages := (len(states) + linesPerPage - 1) / linesPerPage
+	for i := 0; i < len(states); i += linesPerPage {
+		clearImage(img)
+		end := min(i+linesPerPage, len(states))
+
+		title := "Services"
+		if pages > 1 {
+			title = fmt.Sprintf("Services %d/%d", i/linesPerPage+1, pages)
+		}
+		drawHeader(img, title)
+
+		for j, state := range states[i:end] {
+			mark := state.label()
+			// 名稱在狀態左邊，過長時省略，直向畫面狀態在下一行
+			y := 16 + lineHeight*rows*j
+			split, markY := img.Bounds().Dx()-textWidth(mark)-4, y
+			if rows > 1 {
+				split, markY = img.Bounds().Dx(), y+lineHeight
+			}
+			drawTextAligned(img, 0, split, y, strings.TrimSuffix(state.Name, ".service"), alignLeft)
+			drawTextAligned(img, 0, img.Bounds().Dx(), markY, mark, alignRight)
+		}
+		drawFooter(img)
+
+		if end < len(states) {
+			// 更新顯示
+			s.updateDisplay()
+			if !s.holdPage(page, s.pageTime()) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// 取 .env 檔案中的 systemd 服務設定，以逗號分隔
+func shouldShowSystemd() (bool, []string) {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	showSystemdStr := envConfig["SHOW_SYSTEMD"]
+	var units []string
+	for _, unit := range strings.Split(envConfig["SYSTEMD_UNITS"], ",") {
+		unit = strings.TrimSpace(unit)
+		if unit == "" {
+			continue
+		}
+		if !strings.Contains(unit, ".") {
+			unit += ".service"
+		}
+		units = append(units, unit)
+	}
+	return strings.ToLower(showSystemdStr) == "true", units
+}
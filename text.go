@@ -0,0 +1,165 @@
+// 文字排版：依字型實際像素寬度置中、對齊、換行與省略
+package main
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+// 文字對齊方式
+type textAlign int
+
+const (
+	alignLeft textAlign = iota
+	alignCenter
+	alignRight
+)
+
+// 超出寬度時加在結尾的省略符號，內建字型沒有 …
+const ellipsis = ".."
+
+// 在 x0 ~ x1 之間依對齊方式繪製文字，超出寬度時省略
+func drawTextAligned(img *image1bit.VerticalLSB, x0, x1, y int, text string, align textAlign) {
+	text = ellipsize(text, x1-x0)
+	width := textWidth(text)
+	x := x0
+	switch align {
+	case alignCenter:
+		x = x0 + (x1-x0-width)/2
+	case alignRight:
+		x = x1 - width
+	}
+	drawText(img, x, y, text)
+}
+
+// 頁面標題，置中於整個畫面寬度
+func drawTitle(img *image1bit.VerticalLSB, title string) {
+	drawTextAligned(img, 0, img.Bounds().Dx(), 0, title, alignCenter)
+}
+
+// 頁面標題與下方分隔線，精簡版面沒有分隔線
+func drawHeader(img *image1bit.VerticalLSB, title string) {
+	drawTitle(img, title)
+	if !compactLayout(img) {
+		drawText(img, 0, 3, "___________________")
+	}
+}
+
+// 頁面底部分隔線，位於畫面最下方，精簡版面沒有分隔線
+func drawFooter(img *image1bit.VerticalLSB) {
+	if !compactLayout(img) {
+		drawText(img, 0, img.Bounds().Dy()-14, "___________________")
+	}
+}
+
+// 分隔線之間可以顯示的文字行數
+func contentLines(img *image1bit.VerticalLSB) int {
+	const lineHeight = 11
+	bottom := img.Bounds().Dy()
+	if !compactLayout(img) {
+		bottom -= 14
+	}
+	return max((bottom-16)/lineHeight, 1)
+}
+
+// 清單每一項佔用的行數，直向畫面名稱與狀態分成兩行
+func itemLines(img *image1bit.VerticalLSB) int {
+	if portraitLayout(img) {
+		return 2
+	}
+	return 1
+}
+
+// 從分隔線下方依序繪製文字，超出的行不繪製
+func drawLines(img *image1bit.VerticalLSB, lines []string) {
+	const lineHeight = 11
+	// 直向畫面寬度不足，過長的行換行顯示
+	if portraitLayout(img) {
+		var wrapped []string
+		for _, line := range lines {
+			wrapped = append(wrapped, wrapText(line, img.Bounds().Dx())...)
+		}
+		lines = wrapped
+	}
+	for i, line := range lines[:min(len(lines), contentLines(img))] {
+		drawTextAligned(img, 0, img.Bounds().Dx(), 16+lineHeight*i, line, alignLeft)
+	}
+}
+
+// 超出寬度時截斷並加上省略符號，以字元 (rune) 為單位
+func ellipsize(s string, width int) string {
+	if textWidth(s) <= width {
+		return s
+	}
+	limit := width - textWidth(ellipsis)
+	runes := []rune(s)
+	for len(runes) > 0 && textWidth(string(runes)) > limit {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + ellipsis
+}
+
+// 依像素寬度換行，英文在空白處斷行，中文或過長的單字在字元邊界斷行
+func wrapText(s string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		line := ""
+		for _, word := range splitWords(paragraph) {
+			candidate := line + word
+			if line == "" {
+				candidate = strings.TrimLeft(word, " ")
+			}
+			if textWidth(candidate) <= width {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			// 單字本身超出寬度時逐字切割
+			line = ""
+			for _, r := range strings.TrimLeft(word, " ") {
+				if line != "" && textWidth(line+string(r)) > width {
+					lines = append(lines, line)
+					line = ""
+				}
+				line += string(r)
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// 切割為可斷行的片段，空白保留在單字前方，中日韓文字每個字元各自成為一段
+func splitWords(s string) []string {
+	var words []string
+	start := 0
+	for i, r := range s {
+		switch {
+		case r == ' ' && i > start:
+			words = append(words, s[start:i])
+			start = i
+		case isWideRune(r):
+			if i > start {
+				words = append(words, s[start:i])
+			}
+			words = append(words, s[i:i+utf8.RuneLen(r)])
+			start = i + utf8.RuneLen(r)
+		}
+	}
+	if start < len(s) {
+		words = append(words, s[start:])
+	}
+	return words
+}
+
+// 中日韓文字，可在任意字元之間斷行
+func isWideRune(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r) ||
+		(r >= 0x3000 && r <= 0x303F) || (r >= 0xFF00 && r <= 0xFFEF)
+}
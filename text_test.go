@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"slices"
+	"testing"
+
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+func TestFitDigits(t *testing.T) {
+	tests := []struct {
+		height, width int
+		text          string
+		want          int
+	}{
+		{32, 128, "45.3°C", 32},
+		{32, 64, "45.3°C", 21},
+		{18, 44, "1863.02", 11},
+		{28, 4, "100%", 8},
+	}
+	for _, tt := range tests {
+		got := fitDigits(tt.height, tt.width, tt.text)
+		if got != tt.want {
+			t.Errorf("fitDigits(%d, %d, %q) = %d, want %d", tt.height, tt.width, tt.text, got, tt.want)
+		}
+		if got > 8 && digitsWidth(got, tt.text) > tt.width {
+			t.Errorf("fitDigits(%d, %d, %q): width %d exceeds %d", tt.height, tt.width, tt.text, digitsWidth(got, tt.text), tt.width)
+		}
+	}
+}
+
+// 直向畫面寬度不足時換行，橫向畫面維持一行
+func TestDrawLinesPortrait(t *testing.T) {
+	lines := []string{"Buf 123M  Cac 1.2G", "zRam 2.5x 1.2G"}
+	tests := []struct {
+		w, h int
+		want []string
+	}{
+		{128, 64, lines},
+		{64, 128, []string{"Buf 123M", "Cac 1.2G", "zRam 2.5x", "1.2G"}},
+	}
+	for _, tt := range tests {
+		got := image1bit.NewVerticalLSB(image.Rect(0, 0, tt.w, tt.h))
+		drawLines(got, lines)
+		want := image1bit.NewVerticalLSB(got.Bounds())
+		for i, line := range tt.want {
+			drawText(want, 0, 16+11*i, line)
+		}
+		if !bytes.Equal(got.Pix, want.Pix) {
+			t.Errorf("%dx%d: lines not drawn as %q", tt.w, tt.h, tt.want)
+		}
+	}
+}
+
+// 字型為每字 7 像素的等寬字型，中文字也是 7 像素
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"hello world", []string{"hello", " world"}},
+		{"CPU 使用率 95%", []string{"CPU", " ", "使", "用", "率", " 95%"}},
+		{"溫度45C", []string{"溫", "度", "45C"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := splitWords(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("splitWords(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  []string
+	}{
+		{"Buf 123M Cac 1.2G", 56, []string{"Buf 123M", "Cac 1.2G"}},
+		// 中文與英文混合時，中文字之間也可以斷行
+		{"disk 使用中 ok", 42, []string{"disk 使", "用中 ok"}},
+		// 超出寬度的單字逐字切割，剩餘部分與下一個單字接在同一行
+		{"a supercalifragilistic b", 42, []string{"a", "superc", "alifra", "gilist", "ic b"}},
+		{"exactly six", 42, []string{"exactl", "y six"}},
+		{"ab\ncd", 42, []string{"ab", "cd"}},
+		{"", 42, []string{""}},
+	}
+	for _, tt := range tests {
+		got := wrapText(tt.in, tt.width)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("wrapText(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+		for _, line := range got {
+			if textWidth(line) > tt.width {
+				t.Errorf("wrapText(%q, %d): line %q is %d wide", tt.in, tt.width, line, textWidth(line))
+			}
+		}
+	}
+}
+
+func TestEllipsize(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		// 剛好等於寬度時不省略
+		{"abcdef", 42, "abcdef"},
+		{"abcdefg", 42, "abcd" + ellipsis},
+		{"中文字幕測試", 42, "中文字幕測試"},
+		{"中文字幕測試", 35, "中文字" + ellipsis},
+		{"CPU 使用率", 42, "CPU " + ellipsis},
+		{"abc", 7, ellipsis},
+	}
+	for _, tt := range tests {
+		if got := ellipsize(tt.in, tt.width); got != tt.want {
+			t.Errorf("ellipsize(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+	}
+}
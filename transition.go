@@ -0,0 +1,151 @@
+// 頁面切換效果：水平滑動、垂直捲動、擦除、淡出淡入
+package main
+
+import (
+	"image"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+// 更新顯示，頁面改變時依 TRANSITION 設定播放切換效果
+func (s *screen) updateDisplay() {
+	dev, img := s.dev, s.img
+	page, backward := s.pageDir()
+	effect, fps, duration := transitionConfig()
+	if effect != "none" && s.lastFrame != nil && page != s.lastPage {
+		s.playTransition(s.lastFrame, img, effect, fps, duration, page, backward)
+	}
+
+	if err := dev.Draw(dev.Bounds(), img, image.Point{}); err != nil {
+		log.Fatal(err)
+	}
+	if s.lastFrame == nil || s.lastFrame.Bounds() != img.Bounds() {
+		s.lastFrame = image1bit.NewVerticalLSB(img.Bounds())
+	}
+	copy(s.lastFrame.Pix, img.Pix)
+	s.lastPage = page
+}
+
+// 播放切換效果，backward 為回到上一頁時反方向移動，期間按下按鈕切換頁面時立即結束
+func (s *screen) playTransition(from, to *image1bit.VerticalLSB, effect string, fps int, duration time.Duration, page int, backward bool) {
+	dev := s.dev
+
+	if effect == "fade" {
+		s.fadeContrast(s.contrast, 0, duration/2, fps, page)
+		if err := dev.Draw(dev.Bounds(), to, image.Point{}); err != nil {
+			log.Fatal(err)
+		}
+		s.fadeContrast(0, s.contrast, duration/2, fps, page)
+		// 中途結束時也要恢復對比
+		dev.SetContrast(s.contrast)
+		return
+	}
+
+	frame := image1bit.NewVerticalLSB(to.Bounds())
+	frameTime := time.Second / time.Duration(fps)
+	frames := max(int(duration/frameTime), 1)
+	for i := 1; i < frames; i++ {
+		if s.currentPage() != page {
+			return
+		}
+		start := time.Now()
+		composeTransition(frame, from, to, effect, float64(i)/float64(frames), backward)
+		if err := dev.Draw(dev.Bounds(), frame, image.Point{}); err != nil {
+			log.Fatal(err)
+		}
+		time.Sleep(frameTime - time.Since(start))
+	}
+}
+
+// 依進度 t (0 ~ 1) 合成離開與進入的頁面
+func composeTransition(dst, from, to *image1bit.VerticalLSB, effect string, t float64, backward bool) {
+	w, h := dst.Bounds().Dx(), dst.Bounds().Dy()
+	for y := range h {
+		for x := range w {
+			var bit image1bit.Bit
+			switch effect {
+			case "slide":
+				// 新頁面從右邊推入，上一頁時從左邊推入
+				offset := int(float64(w) * t)
+				if backward {
+					offset = -offset
+				}
+				sx := x + offset
+				switch {
+				case sx >= w:
+					bit = to.BitAt(sx-w, y)
+				case sx < 0:
+					bit = to.BitAt(sx+w, y)
+				default:
+					bit = from.BitAt(sx, y)
+				}
+			case "scroll":
+				// 新頁面從下方推入，上一頁時從上方推入
+				offset := int(float64(h) * t)
+				if backward {
+					offset = -offset
+				}
+				sy := y + offset
+				switch {
+				case sy >= h:
+					bit = to.BitAt(x, sy-h)
+				case sy < 0:
+					bit = to.BitAt(x, sy+h)
+				default:
+					bit = from.BitAt(x, sy)
+				}
+			case "wipe":
+				// 由左往右擦除，上一頁時由右往左
+				edge := int(float64(w) * t)
+				if (!backward && x < edge) || (backward && x >= w-edge) {
+					bit = to.BitAt(x, y)
+				} else {
+					bit = from.BitAt(x, y)
+				}
+			}
+			dst.SetBit(x, y, bit)
+		}
+	}
+}
+
+// 在 duration 內將對比由 from 漸變到 to
+func (s *screen) fadeContrast(from, to byte, duration time.Duration, fps int, page int) {
+	frameTime := time.Second / time.Duration(fps)
+	steps := max(int(duration/frameTime), 1)
+	for i := 1; i <= steps; i++ {
+		if s.currentPage() != page {
+			return
+		}
+		level := int(from) + (int(to)-int(from))*i/steps
+		if err := s.dev.SetContrast(byte(level)); err != nil {
+			log.Printf("設定對比失敗: %v", err)
+			return
+		}
+		time.Sleep(frameTime)
+	}
+}
+
+// 取 .env 檔案中的 TRANSITION、TRANSITION_FPS、TRANSITION_MS 設定
+func transitionConfig() (string, int, time.Duration) {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	effect := strings.ToLower(strings.TrimSpace(envConfig["TRANSITION"]))
+	switch effect {
+	case "slide", "scroll", "wipe", "fade":
+	default:
+		effect = "none" // 預設值
+	}
+	fps, err := strconv.Atoi(envConfig["TRANSITION_FPS"])
+	if err != nil || fps <= 0 {
+		fps = 30 // 預設值
+	}
+	ms, err := strconv.Atoi(envConfig["TRANSITION_MS"])
+	if err != nil || ms <= 0 {
+		ms = 300 // 預設值
+	}
+	return effect, fps, time.Duration(ms) * time.Millisecond
+}
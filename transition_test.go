@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"periph.io/x/devices/v3/ssd1306/image1bit"
+)
+
+func TestComposeTransition(t *testing.T) {
+	// 水平效果：離開的頁面點亮第 2、5 行，進入的頁面點亮第 1、6 行
+	hFrom, hTo := bitImage("..#..#.."), bitImage(".#....#.")
+	// 垂直效果：離開的頁面點亮第 2、5 列，進入的頁面點亮第 1、6 列
+	vFrom := bitImage("..", "..", "##", "..", "..", "##", "..", "..")
+	vTo := bitImage("..", "##", "..", "..", "..", "..", "##", "..")
+
+	tests := []struct {
+		effect   string
+		t        float64
+		backward bool
+		want     []string
+	}{
+		{"slide", 0, false, []string{"..#..#.."}},
+		{"slide", 0.5, false, []string{".#...#.."}},
+		{"slide", 1, false, []string{".#....#."}},
+		{"slide", 0, true, []string{"..#..#.."}},
+		{"slide", 0.5, true, []string{"..#...#."}},
+		{"slide", 1, true, []string{".#....#."}},
+		{"wipe", 0, false, []string{"..#..#.."}},
+		{"wipe", 0.5, false, []string{".#...#.."}},
+		{"wipe", 1, false, []string{".#....#."}},
+		{"wipe", 0.5, true, []string{"..#...#."}},
+		{"wipe", 1, true, []string{".#....#."}},
+		{"scroll", 0, false, []string{"..", "..", "##", "..", "..", "##", "..", ".."}},
+		{"scroll", 0.5, false, []string{"..", "##", "..", "..", "..", "##", "..", ".."}},
+		{"scroll", 1, false, []string{"..", "##", "..", "..", "..", "..", "##", ".."}},
+		{"scroll", 0.5, true, []string{"..", "..", "##", "..", "..", "..", "##", ".."}},
+		{"scroll", 1, true, []string{"..", "##", "..", "..", "..", "..", "##", ".."}},
+	}
+	for _, tt := range tests {
+		from, to := hFrom, hTo
+		if tt.effect == "scroll" {
+			from, to = vFrom, vTo
+		}
+		dst := image1bit.NewVerticalLSB(from.Bounds())
+		composeTransition(dst, from, to, tt.effect, tt.t, tt.backward)
+		if got := pixelRows(dst); strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("%s t=%v backward=%v: got %q, want %q", tt.effect, tt.t, tt.backward, got, tt.want)
+		}
+	}
+}
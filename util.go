@@ -2,34 +2,34 @@
 package main
 
 import (
-	"strings"
+	"fmt"
 )
 
 // "golang.org/x/text/message"
 
 // p := message.NewPrinter(message.MatchLanguage("en"))
 
-// 切割字串為 N 個字符的片段
-func splitByN(s string, n int) []string {
-	var result []string
-	for i := 0; i < len(s); i += n {
-		end := i + n
-		if end > len(s) {
-			end = len(s)
-		}
-		result = append(result, s[i:end])
+// 依大小自動選擇 GB / MB / KB 單位，數值最多 4 個字元
+func formatBytes(b float64) (string, string) {
+	value, unit := b/1024, "KB"
+	switch {
+	case b >= 1024*1024*1024:
+		value, unit = b/1024/1024/1024, "GB"
+	case b >= 1024*1024:
+		value, unit = b/1024/1024, "MB"
 	}
-	return result
+	// 以四捨五入後的長度決定位數，9.996 進位成 10.00 時改用 10.0
+	if text := fmt.Sprintf("%.2f", value); len(text) <= 4 {
+		return text, unit
+	}
+	if text := fmt.Sprintf("%.1f", value); len(text) <= 4 {
+		return text, unit
+	}
+	return fmt.Sprintf("%.0f", value), unit
 }
 
-// 讓文字能置中，計算字串長度加入空白的字元
-func testCenter(str string, width int) string {
-	// 計算需要填充的空格數量
-	// spaces := (width - len(str)) / 2
-	// if spaces < 0 {
-	// 	spaces = 0
-	// }
-	spaces := max((width-len(str))/2, 0)
-	// 在字串前面添加空格
-	return strings.Repeat(" ", spaces) + str
+// 精簡格式，例如 1.25G、812M
+func formatBytesShort(b float64) string {
+	value, unit := formatBytes(b)
+	return value + unit[:1]
 }
@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		b     float64
+		value string
+		unit  string
+	}{
+		{512, "0.50", "KB"},
+		{9.994 * 1024, "9.99", "KB"},
+		// 9.995 在 float64 中略小於 9.995，四捨五入後仍是 9.99
+		{9.995 * 1024, "9.99", "KB"},
+		{9.996 * 1024, "10.0", "KB"},
+		{99.94 * 1024 * 1024, "99.9", "MB"},
+		{99.96 * 1024 * 1024, "100", "MB"},
+		{1.25 * 1024 * 1024 * 1024, "1.25", "GB"},
+	}
+	for _, tt := range tests {
+		value, unit := formatBytes(tt.b)
+		if value != tt.value || unit != tt.unit {
+			t.Errorf("formatBytes(%v) = %s %s, want %s %s", tt.b, value, unit, tt.value, tt.unit)
+		}
+	}
+}